@@ -17,21 +17,75 @@ limitations under the License.
 package v1
 
 import (
+	"sync/atomic"
+	"time"
+
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // ActionSpec defines the desired state of Action.
 type ActionSpec struct {
-	// Op represents the kind of operation for current mino, for instance "left", "right", "down", "rot", or "drop".
+	// Op represents the kind of operation for current mino, for instance "left", "right", "down", "rotate", "rotateCCW", "rotate180", "drop", or "hold".
 	Op string `json:"op"`
+
+	// ID of the player whose current mino is moved in co-op mode. An Action without a player, for instance "down" from Cron, moves all the current minoes.
+	Player string `json:"player,omitempty"`
+
+	// Sequence number of the Action. The Board controller applies the Actions in ascending order of it.
+	// t4s-app and Cron set it from the clock in nanoseconds, because creationTimestamp has a resolution of a second.
+	// +optional
+	Sequence int64 `json:"sequence,omitempty"`
+}
+
+// lastActionSequence is the sequence number returned by NewActionSequence last time.
+var lastActionSequence int64
+
+// NewActionSequence returns the sequence number of a new Action.
+// It is the current time in nanoseconds, but always greater than the one returned before in the process.
+func NewActionSequence() int64 {
+	for {
+		last := atomic.LoadInt64(&lastActionSequence)
+		seq := time.Now().UnixNano()
+		if seq <= last {
+			seq = last + 1
+		}
+		if atomic.CompareAndSwapInt64(&lastActionSequence, last, seq) {
+			return seq
+		}
+	}
 }
 
 // ActionStatus defines the observed state of Action.
 type ActionStatus struct {
+	// Outcome of the Action. Possible values are "Applied", "Blocked", "Ignored" and "Invalid". It is empty until the Action is processed.
+	Result ActionResult `json:"result,omitempty"`
+
+	// Time when the Action is processed by the Board controller
+	ProcessedAt *metav1.MicroTime `json:"processedAt,omitempty"`
+
+	// (Absolute) coordinates of the current mino of the player after the Action is processed
+	Coords []Coord `json:"coords,omitempty"`
 }
 
+// ActionResult defines the outcome of an Action
+// +kubebuilder:validation:Enum=Applied;Blocked;Ignored;Invalid
+type ActionResult string
+
+const (
+	// The Action has been applied to the board.
+	ActionApplied = ActionResult("Applied")
+	// The Action could not be applied because of a collision or a restriction like the hold limit.
+	ActionBlocked = ActionResult("Blocked")
+	// The Action has been ignored because the game is not being played, for instance it is paused or over.
+	ActionIgnored = ActionResult("Ignored")
+	// The op of the Action is unknown.
+	ActionInvalid = ActionResult("Invalid")
+)
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
+//+kubebuilder:printcolumn:name="OP",type="string",JSONPath=".spec.op"
+//+kubebuilder:printcolumn:name="RESULT",type="string",JSONPath=".status.result"
 
 // Action is the Schema for the actions API.
 type Action struct {
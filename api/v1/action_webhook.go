@@ -0,0 +1,83 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Ops supported by Action
+var validOps = map[string]bool{
+	"left":      true,
+	"right":     true,
+	"down":      true,
+	"rotate":    true,
+	"rotateCCW": true,
+	"rotate180": true,
+	"drop":      true,
+	"hold":      true,
+}
+
+func SetupActionWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&Action{}).
+		WithValidator(&actionValidator{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-t4s-tkna-net-v1-action,mutating=false,failurePolicy=fail,sideEffects=None,groups=t4s.tkna.net,resources=actions,verbs=create;update,versions=v1,name=vaction.kb.io,admissionReviewVersions=v1
+
+type actionValidator struct{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (v actionValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	logger := log.FromContext(ctx)
+	action := obj.(*Action)
+	logger.Info("validate create", "name", action.Name)
+
+	return v.validateOp(ctx, action)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (v actionValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	logger := log.FromContext(ctx)
+	action := newObj.(*Action)
+	logger.Info("validate update", "name", action.Name)
+
+	return v.validateOp(ctx, action)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (v actionValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func (v actionValidator) validateOp(ctx context.Context, action *Action) error {
+	logger := log.FromContext(ctx)
+
+	if !validOps[action.Spec.Op] {
+		err := fmt.Errorf("unknown op: %q", action.Spec.Op)
+		logger.Error(err, "invalid Action", "name", action.Name)
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,42 @@
+package v1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Action Webhook Test", func() {
+	ctx := context.Background()
+
+	It("should create an Action with a known op", func() {
+		action := &Action{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "action-valid",
+			},
+			Spec: ActionSpec{
+				Op: "rotateCCW",
+			},
+		}
+		err := k8sClient.Create(ctx, action)
+		Expect(err).ShouldNot(HaveOccurred())
+	})
+
+	It("should not create an Action with an unknown op", func() {
+		action := &Action{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "action-invalid",
+			},
+			Spec: ActionSpec{
+				Op: "rotat",
+			},
+		}
+		err := k8sClient.Create(ctx, action)
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Error()).Should(ContainSubstring("unknown op"))
+	})
+})
@@ -37,21 +37,186 @@ type BoardSpec struct {
 	//+kubebuilder:default=1000
 	Wait int `json:"wait,omitempty"`
 
-	// Desired state of the board. Possible values are "Playing" and "GameOver".
+	// Speed curve of the board. Each entry overrides Wait from its level onwards. This value is inherited by Cron.
+	SpeedCurve []SpeedLevel `json:"speedCurve,omitempty"`
+
+	// Randomizer which decides the order of the minoes (default: Bag). Possible values are "Uniform" and "Bag".
+	// "Uniform" picks every mino at random independently, and "Bag" (also known as 7-bag) deals all the minoes in random order before any of them repeats.
+	//+kubebuilder:default="Bag"
+	Randomizer Randomizer `json:"randomizer,omitempty"`
+
+	// Number of the next minoes shown in advance (default: 3)
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:default=3
+	NextCount int `json:"nextCount,omitempty"`
+
+	// Lock delay in millisec, the grace period before the mino is fixed after it touches the ground.
+	// Moving or rotating the mino on the ground resets the lock delay. 0 fixes the mino as soon as it touches the ground.
+	//+kubebuilder:validation:Minimum=0
+	LockDelay int `json:"lockDelay,omitempty"`
+
+	// Maximum number of times the lock delay can be reset by moving or rotating a mino (default: 15)
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:default=15
+	MaxLockResets int `json:"maxLockResets,omitempty"`
+
+	// Game mode (default: Marathon). Possible values are "Marathon", "Sprint", "Ultra" and "Puzzle".
+	// "Marathon" goes on until the mino cannot appear, "Sprint" is cleared by removing GoalLines lines, and "Ultra" is cleared when TimeLimitSeconds has passed.
+	// "Puzzle" is cleared by reaching PuzzleGoal with the minoes in Sequence, and is over when they run out.
+	//+kubebuilder:default="Marathon"
+	Mode GameMode `json:"mode,omitempty"`
+
+	// Number of lines to clear in Sprint mode, or in Puzzle mode when PuzzleGoal is "ClearLines" (default: 40)
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:default=40
+	GoalLines int `json:"goalLines,omitempty"`
+
+	// Time limit of the game in seconds in Ultra mode (default: 120). The time paused is not counted.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:default=120
+	TimeLimitSeconds int `json:"timeLimitSeconds,omitempty"`
+
+	// Goal of Puzzle mode (default: ClearLines). Possible values are "ClearLines", which clears GoalLines lines, and "ClearBoard", which removes all the blocks.
+	//+kubebuilder:default="ClearLines"
+	PuzzleGoal PuzzleGoal `json:"puzzleGoal,omitempty"`
+
+	// Initial cells of the board from the top row to the bottom, each of which has Width Mino IDs (0 is an empty cell).
+	// It must have Height rows if specified.
+	InitialData [][]int `json:"initialData,omitempty"`
+
+	// Fixed sequence of the Mino IDs which appear in order, instead of those generated by Randomizer. No more mino appears after the sequence runs out.
+	Sequence []int `json:"sequence,omitempty"`
+
+	// Idle timeout in seconds. The game is stopped when no Action is requested by the user for this period, and the Cron is removed. 0 disables the idle timeout.
+	//+kubebuilder:validation:Minimum=0
+	IdleTimeoutSeconds int `json:"idleTimeoutSeconds,omitempty"`
+
+	// State of the board after the idle timeout (default: Paused). Possible values are "Paused" and "GameOver".
+	//+kubebuilder:default="Paused"
+	IdleState IdleState `json:"idleState,omitempty"`
+
+	// IDs of the players in co-op mode. Each player has their own current mino on the board, which is moved by the Actions with the player ID.
+	// The board should be wide enough for all of them. If empty, the game is played by a single player.
+	Players []string `json:"players,omitempty"`
+
+	// Names of the opponent Boards in the same namespace in versus mode. Lines cleared on this Board are sent to them as garbage rows.
+	Opponents []string `json:"opponents,omitempty"`
+
+	// Seed of the random numbers used in the game. The same seed and the same sequence of Actions always reproduce the same game.
+	// If not specified, it is generated automatically and recorded in the status.
+	Seed int64 `json:"seed,omitempty"`
+
+	// Desired state of the board. Possible values are "Playing", "Paused" and "GameOver".
+	// A game being played can be paused and resumed by switching it between "Playing" and "Paused".
 	//+kubebuilder:default="GameOver"
 	State BoardState `json:"state,omitempty"`
 }
 
+// SpeedLevel defines the wait time applied from a level.
+type SpeedLevel struct {
+	// Level from which the wait time is applied
+	//+kubebuilder:validation:Minimum=1
+	Level int `json:"level"`
+
+	// Wait time when a mino falls in millisec. It cannot go below 200.
+	//+kubebuilder:validation:Minimum=200
+	Wait int `json:"wait"`
+}
+
 // BoardStatus defines the observed state of Board.
 type BoardStatus struct {
 	// Board Data
 	Data [][]int `json:"data,omitempty"`
 
-	// Current Mino Data
+	// Current Mino Data. There is one current mino for each player in co-op mode.
 	CurrentMino []CurrentMino `json:"currentMino,omitempty"`
 
-	// Current state of the board. Possible values are "Playing" and "GameOver".
+	// IDs of the next minoes in the order they appear
+	Next []int `json:"next,omitempty"`
+
+	// IDs of the minoes left in the current bag when the randomizer is "Bag"
+	Bag []int `json:"bag,omitempty"`
+
+	// ID of the held mino. The hold is shared by the players in co-op mode.
+	HoldMino int `json:"holdMino,omitempty"`
+
+	// Seed of the random numbers used in the game
+	Seed int64 `json:"seed,omitempty"`
+
+	// Internal state of the random number generator derived from Seed
+	RandomState int64 `json:"randomState,omitempty"`
+
+	// Number of the minoes taken from Spec.Sequence
+	SequenceIndex int `json:"sequenceIndex,omitempty"`
+
+	// Current state of the board. Possible values are "Playing", "Paused", "GameOver" and "Cleared".
+	// When the game is cleared, ElapsedTime is the completion time and Score is the final score.
 	State BoardState `json:"state,omitempty"`
+
+	// Reason why the game has been stopped by the Board controller, for instance "IdleTimeout", "GoalReached", "TimeUp", "OutOfMinoes", "Won" or "ToppedOut". It is empty while the game is being played.
+	StateReason string `json:"stateReason,omitempty"`
+
+	// Time when the last Action requested by the user was applied, or when the game was started or resumed. It is used for the idle timeout.
+	LastUserActionTime *metav1.MicroTime `json:"lastUserActionTime,omitempty"`
+
+	// Actions which have been applied to the board but may not be deleted or updated yet.
+	// They are not applied again even if the Board controller fails to delete them or to record their results after the board is saved.
+	AppliedActions []AppliedAction `json:"appliedActions,omitempty"`
+
+	// Play time of the game in millisec, excluding the time paused. It is updated in every reconciliation while the game is being played.
+	ElapsedTime int64 `json:"elapsedTime,omitempty"`
+
+	// Time when ElapsedTime was last updated. It is empty unless the game is being played.
+	// The current play time is ElapsedTime plus the time passed since then.
+	ElapsedTimeUpdatedAt *metav1.MicroTime `json:"elapsedTimeUpdatedAt,omitempty"`
+
+	// Total score of the game
+	Score int `json:"score,omitempty"`
+
+	// Total number of cleared lines
+	Lines int `json:"lines,omitempty"`
+
+	// Current level. It starts from 1 and goes up every 10 cleared lines.
+	Level int `json:"level,omitempty"`
+
+	// Number of minoes placed on the board
+	Pieces int `json:"pieces,omitempty"`
+
+	// Y coordinates of the rows removed by the last fixed mino, counted before the removal. The client can use it to animate the removal.
+	ClearedRows []int `json:"clearedRows,omitempty"`
+
+	// Type of the last line clear or T-spin, for instance "Double", "TSpinSingle" or "TSpinMini". It is kept until the next one.
+	LastClear ClearType `json:"lastClear,omitempty"`
+
+	// Number of consecutive minoes which have cleared lines. It is reset to 0 by a mino which clears no lines.
+	Combo int `json:"combo,omitempty"`
+
+	// Number of consecutive difficult line clears, i.e. quads and T-spins with lines. It is reset to 0 by any other line clear.
+	BackToBack int `json:"backToBack,omitempty"`
+
+	// Total number of garbage rows sent to the opponents in versus mode
+	GarbageSent int `json:"garbageSent,omitempty"`
+
+	// Number of garbage rows taken so far from GarbageSent of each opponent
+	GarbageReceived map[string]int `json:"garbageReceived,omitempty"`
+
+	// Number of garbage rows received but not pushed into Data yet. They are pushed when a mino is fixed without removing rows.
+	PendingGarbage int `json:"pendingGarbage,omitempty"`
+
+	// Name of the Board which has won the versus game, i.e. the last one which has not topped out
+	Winner string `json:"winner,omitempty"`
+
+	// Effective wait time in millisec at the current level
+	Wait int `json:"wait,omitempty"`
+}
+
+// AppliedAction records an Action applied to the board.
+type AppliedAction struct {
+	// Name of the Action
+	Name string `json:"name"`
+
+	// Status of the Action to be recorded when the processed Actions are kept
+	Status ActionStatus `json:"status,omitempty"`
 }
 
 type Coord struct {
@@ -65,15 +230,51 @@ type CurrentMino struct {
 	Center         Coord   `json:"center,omitempty"`
 	RelativeCoords []Coord `json:"relativeCoords,omitempty"`
 	AbsoluteCoords []Coord `json:"absoluteCoords,omitempty"`
+
+	// Rotation state: 0 (spawn), 1 (clockwise), 2 (180 degrees) or 3 (counter-clockwise)
+	Rotation int `json:"rotation,omitempty"`
+
+	// Whether the last successful move of the mino was a rotation. It is used to detect T-spins.
+	Rotated bool `json:"rotated,omitempty"`
+
+	// Index of the wall kick offset used by the last rotation
+	KickIndex int `json:"kickIndex,omitempty"`
+
+	// (Absolute) coordinates where the mino lands if it is dropped. The client can draw a "ghost" of the mino there.
+	GhostCoords []Coord `json:"ghostCoords,omitempty"`
+
+	// ID of the player who moves the mino in co-op mode. It is empty for a single player.
+	Player string `json:"player,omitempty"`
+
+	// Time when the lock delay of the mino started. It is empty while the mino is in the air.
+	LockStartTime *metav1.MicroTime `json:"lockStartTime,omitempty"`
+
+	// Number of times the lock delay has been reset for the mino
+	LockResets int `json:"lockResets,omitempty"`
+
+	// Whether the hold has been used since the mino appeared. It is allowed only once per mino.
+	HoldUsed bool `json:"holdUsed,omitempty"`
 }
 
 func (mino CurrentMino) DeepCopy() CurrentMino {
 	newMino := CurrentMino{
 		MinoID:         mino.MinoID,
 		Center:         Coord{X: mino.Center.X, Y: mino.Center.Y},
+		Rotation:       mino.Rotation,
+		Rotated:        mino.Rotated,
+		KickIndex:      mino.KickIndex,
+		Player:         mino.Player,
+		LockResets:     mino.LockResets,
+		HoldUsed:       mino.HoldUsed,
 		RelativeCoords: []Coord{},
 		AbsoluteCoords: []Coord{},
 	}
+	if mino.LockStartTime != nil {
+		newMino.LockStartTime = mino.LockStartTime.DeepCopy()
+	}
+	if mino.GhostCoords != nil {
+		newMino.GhostCoords = append([]Coord{}, mino.GhostCoords...)
+	}
 	for _, v := range mino.RelativeCoords {
 		newMino.RelativeCoords = append(newMino.RelativeCoords, Coord{X: v.X, Y: v.Y})
 	}
@@ -83,15 +284,91 @@ func (mino CurrentMino) DeepCopy() CurrentMino {
 	return newMino
 }
 
+// ClearType is the type of a line clear or a T-spin
+// +kubebuilder:validation:Enum=Single;Double;Triple;Quad;TSpinMini;TSpinMiniSingle;TSpinMiniDouble;TSpin;TSpinSingle;TSpinDouble;TSpinTriple
+type ClearType string
+
+const (
+	Single          = ClearType("Single")
+	Double          = ClearType("Double")
+	Triple          = ClearType("Triple")
+	Quad            = ClearType("Quad")
+	TSpinMini       = ClearType("TSpinMini")
+	TSpinMiniSingle = ClearType("TSpinMiniSingle")
+	TSpinMiniDouble = ClearType("TSpinMiniDouble")
+	TSpin           = ClearType("TSpin")
+	TSpinSingle     = ClearType("TSpinSingle")
+	TSpinDouble     = ClearType("TSpinDouble")
+	TSpinTriple     = ClearType("TSpinTriple")
+)
+
+// Randomizer defines how the minoes are generated
+// +kubebuilder:validation:Enum=Uniform;Bag
+type Randomizer string
+
+const (
+	Uniform = Randomizer("Uniform")
+	Bag     = Randomizer("Bag")
+)
+
 // BoardState defines the state of Board
-// +kubebuilder:validation:Enum=Playing;GameOver
+// +kubebuilder:validation:Enum=Playing;Paused;GameOver;Cleared
 type BoardState string
 
 const (
 	Playing  = BoardState("Playing")
+	Paused   = BoardState("Paused")
 	GameOver = BoardState("GameOver")
+	Cleared  = BoardState("Cleared")
 )
 
+// GameMode defines the rule to finish the game
+// +kubebuilder:validation:Enum=Marathon;Sprint;Ultra;Puzzle
+type GameMode string
+
+const (
+	Marathon = GameMode("Marathon")
+	Sprint   = GameMode("Sprint")
+	Ultra    = GameMode("Ultra")
+	Puzzle   = GameMode("Puzzle")
+)
+
+// PuzzleGoal defines the goal of Puzzle mode
+// +kubebuilder:validation:Enum=ClearLines;ClearBoard
+type PuzzleGoal string
+
+const (
+	ClearLines = PuzzleGoal("ClearLines")
+	ClearBoard = PuzzleGoal("ClearBoard")
+)
+
+// IdleState defines the state of Board after the idle timeout
+// +kubebuilder:validation:Enum=Paused;GameOver
+type IdleState string
+
+const (
+	IdlePaused   = IdleState("Paused")
+	IdleGameOver = IdleState("GameOver")
+)
+
+const (
+	// ReasonIdleTimeout is the reason of the state when the game is stopped by the idle timeout.
+	ReasonIdleTimeout = "IdleTimeout"
+	// ReasonGoalReached is the reason of the state when the goal is reached in Sprint or Puzzle mode.
+	ReasonGoalReached = "GoalReached"
+	// ReasonTimeUp is the reason of the state when the time limit has passed in Ultra mode.
+	ReasonTimeUp = "TimeUp"
+	// ReasonOutOfMinoes is the reason of the state when the minoes in the sequence run out before the goal is reached.
+	ReasonOutOfMinoes = "OutOfMinoes"
+	// ReasonWon is the reason of the state when all the opponents have topped out in versus mode.
+	ReasonWon = "Won"
+	// ReasonToppedOut is the reason of the state when the garbage rows push the blocks out of the top of the board in versus mode.
+	ReasonToppedOut = "ToppedOut"
+)
+
+// GarbageCell is the value of the cells of the garbage rows in Status.Data.
+const GarbageCell = -1
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="WIDTH",type="integer",JSONPath=".spec.width"
@@ -0,0 +1,109 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+func SetupBoardWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&Board{}).
+		WithValidator(&boardValidator{}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-t4s-tkna-net-v1-board,mutating=false,failurePolicy=fail,sideEffects=None,groups=t4s.tkna.net,resources=boards,verbs=create;update,versions=v1,name=vboard.kb.io,admissionReviewVersions=v1
+
+type boardValidator struct{}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (v boardValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	logger := log.FromContext(ctx)
+	board := obj.(*Board)
+	logger.Info("validate create", "name", board.Name)
+
+	if err := validateBoardSpec(board.Name, board.Spec); err != nil {
+		logger.Error(err, "failed to create Board", "name", board.Name)
+		return err
+	}
+	return nil
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+// The size of the board cannot be changed because Status.Data is allocated when the Board is created.
+func (v boardValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	logger := log.FromContext(ctx)
+	oldBoard := oldObj.(*Board)
+	newBoard := newObj.(*Board)
+	logger.Info("validate update", "name", newBoard.Name)
+
+	if oldBoard.Spec.Width != newBoard.Spec.Width || oldBoard.Spec.Height != newBoard.Spec.Height {
+		err := fmt.Errorf("width and height of Board are immutable. width: %v -> %v, height: %v -> %v",
+			oldBoard.Spec.Width, newBoard.Spec.Width, oldBoard.Spec.Height, newBoard.Spec.Height)
+		logger.Error(err, "failed to update Board", "name", newBoard.Name)
+		return err
+	}
+	if err := validateBoardSpec(newBoard.Name, newBoard.Spec); err != nil {
+		logger.Error(err, "failed to update Board", "name", newBoard.Name)
+		return err
+	}
+	return nil
+}
+
+// validateBoardSpec checks that the initial cells fit the board, Puzzle mode has minoes to play and the Board is not its own opponent.
+func validateBoardSpec(name string, spec BoardSpec) error {
+	if spec.InitialData != nil {
+		if len(spec.InitialData) != spec.Height {
+			return fmt.Errorf("initialData must have %v rows. rows: %v", spec.Height, len(spec.InitialData))
+		}
+		for y, row := range spec.InitialData {
+			if len(row) != spec.Width {
+				return fmt.Errorf("each row of initialData must have %v cells. y: %v, cells: %v", spec.Width, y, len(row))
+			}
+			for x, cell := range row {
+				if cell < 0 {
+					return fmt.Errorf("cells of initialData must not be negative. x: %v, y: %v, cell: %v", x, y, cell)
+				}
+			}
+		}
+	}
+	for i, id := range spec.Sequence {
+		if id <= 0 {
+			return fmt.Errorf("minoId in sequence must be positive. index: %v, minoId: %v", i, id)
+		}
+	}
+	if spec.Mode == Puzzle && len(spec.Sequence) == 0 {
+		return fmt.Errorf("sequence is required in Puzzle mode")
+	}
+	for _, opponent := range spec.Opponents {
+		if opponent == name {
+			return fmt.Errorf("opponents must not include the Board itself. name: %v", name)
+		}
+	}
+	return nil
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (v boardValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
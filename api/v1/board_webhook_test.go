@@ -0,0 +1,94 @@
+package v1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Board Webhook Test", func() {
+	ctx := context.Background()
+
+	It("should not change the size of a Board", func() {
+		By("creating a Board")
+		board := &Board{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "board-size",
+			},
+			Spec: BoardSpec{
+				Width:  10,
+				Height: 20,
+				Wait:   1000,
+			},
+		}
+		err := k8sClient.Create(ctx, board)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		By("updating the wait time")
+		board.Spec.Wait = 500
+		err = k8sClient.Update(ctx, board)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		By("updating the width")
+		board.Spec.Width = 12
+		err = k8sClient.Update(ctx, board)
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Error()).Should(ContainSubstring("width and height of Board are immutable"))
+	})
+
+	It("should validate the puzzle", func() {
+		newPuzzle := func(name string, data [][]int, sequence []int) *Board {
+			return &Board{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: "default",
+					Name:      name,
+				},
+				Spec: BoardSpec{
+					Width:       3,
+					Height:      3,
+					Mode:        Puzzle,
+					InitialData: data,
+					Sequence:    sequence,
+				},
+			}
+		}
+
+		By("creating a valid puzzle")
+		err := k8sClient.Create(ctx, newPuzzle("puzzle-valid", [][]int{{0, 0, 0}, {0, 0, 0}, {1, 0, 1}}, []int{2}))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		By("creating a puzzle whose initial cells don't fit the board")
+		err = k8sClient.Create(ctx, newPuzzle("puzzle-rows", [][]int{{0, 0, 0}, {1, 0, 1}}, []int{2}))
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Error()).Should(ContainSubstring("initialData must have 3 rows"))
+
+		err = k8sClient.Create(ctx, newPuzzle("puzzle-cells", [][]int{{0, 0, 0}, {0, 0, 0}, {1, 0}}, []int{2}))
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Error()).Should(ContainSubstring("each row of initialData must have 3 cells"))
+
+		By("creating a puzzle without sequence")
+		err = k8sClient.Create(ctx, newPuzzle("puzzle-no-sequence", nil, nil))
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Error()).Should(ContainSubstring("sequence is required in Puzzle mode"))
+	})
+
+	It("should not create a Board which is its own opponent", func() {
+		board := &Board{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      "versus-self",
+			},
+			Spec: BoardSpec{
+				Width:     10,
+				Height:    20,
+				Opponents: []string{"versus-other", "versus-self"},
+			},
+		}
+		err := k8sClient.Create(ctx, board)
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Error()).Should(ContainSubstring("opponents must not include the Board itself"))
+	})
+})
@@ -24,6 +24,9 @@ import (
 type CronSpec struct {
 	// Cron Controller is reconciled periodically every `Period` millisec.
 	Period int `json:"period"`
+
+	// Suspend stops creating Actions without deleting the Cron, for instance while the game is paused.
+	Suspend bool `json:"suspend,omitempty"`
 }
 
 // CronStatus defines the observed state of Cron.
@@ -33,6 +36,7 @@ type CronStatus struct {
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="PERIOD",type="integer",JSONPath=".spec.period"
+//+kubebuilder:printcolumn:name="SUSPEND",type="boolean",JSONPath=".spec.suspend"
 
 // Cron is the Schema for the crons API.
 type Cron struct {
@@ -30,6 +30,48 @@ type MinoSpec struct {
 
 	// Color of the Mino. It must be a string that Javascript recognizes as color, for instance "blue", "#0000FF" or "rgb(0, 0, 255)".
 	Color string `json:"color,omitempty"`
+
+	// Built-in kick table tried when the Mino rotates (default: Standard). Possible values are "Standard", "I" and "None".
+	// "Standard" and "I" are the wall kicks of the Super Rotation System for J, L, S, T, Z minoes and I mino respectively.
+	//+kubebuilder:default="Standard"
+	KickTable KickTable `json:"kickTable,omitempty"`
+
+	// Custom wall kicks which take precedence over KickTable for the given rotations.
+	Kicks []Kick `json:"kicks,omitempty"`
+
+	// Explicit (relative) coordinates for each rotation state: 0 (spawn), 1 (clockwise), 2 (180 degrees) and 3 (counter-clockwise).
+	// It is used for the minoes which do not rotate around a cell, like I and O minoes.
+	// If not specified, the Mino rotates around its center cell (0, 0).
+	//+kubebuilder:validation:MinItems=4
+	//+kubebuilder:validation:MaxItems=4
+	Rotations [][]Coord `json:"rotations,omitempty"`
+}
+
+// KickTable defines the built-in wall kicks
+// +kubebuilder:validation:Enum=Standard;I;None
+type KickTable string
+
+const (
+	StandardKickTable = KickTable("Standard")
+	IKickTable        = KickTable("I")
+	NoKickTable       = KickTable("None")
+)
+
+// Kick defines the offsets tried in order when a Mino rotates from one rotation state to another.
+// Rotation states are 0 (spawn), 1 (clockwise), 2 (180 degrees) and 3 (counter-clockwise).
+type Kick struct {
+	// Rotation state before the rotation
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:validation:Maximum=3
+	From int `json:"from"`
+
+	// Rotation state after the rotation
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:validation:Maximum=3
+	To int `json:"to"`
+
+	// Offsets of the center tried in order. Positive "y" means upward.
+	Offsets []Coord `json:"offsets"`
 }
 
 // MinoStatus defines the observed state of Mino.
@@ -0,0 +1,148 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Colors which Javascript recognizes: named colors like "blue", hex colors like "#0000FF", and functional notations like "rgb(0, 0, 255)"
+var colorPattern = regexp.MustCompile(`^([a-zA-Z]+|#([0-9a-fA-F]{3,4}|[0-9a-fA-F]{6}|[0-9a-fA-F]{8})|(rgb|rgba|hsl|hsla)\([0-9.,%\s/]+\))$`)
+
+func SetupMinoWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr).
+		For(&Mino{}).
+		WithValidator(&minoValidator{reader: mgr.GetAPIReader()}).
+		Complete()
+}
+
+//+kubebuilder:webhook:path=/validate-t4s-tkna-net-v1-mino,mutating=false,failurePolicy=fail,sideEffects=None,groups=t4s.tkna.net,resources=minoes,verbs=create;update,versions=v1,name=vmino.kb.io,admissionReviewVersions=v1
+
+type minoValidator struct {
+	// Reader which reads the Minoes from kube-apiserver directly, so that a Mino created just before is not missed by the cache
+	reader client.Reader
+}
+
+// ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
+func (v minoValidator) ValidateCreate(ctx context.Context, obj runtime.Object) error {
+	logger := log.FromContext(ctx)
+	mino := obj.(*Mino)
+	logger.Info("validate create", "name", mino.Name)
+
+	return v.validateMino(ctx, mino)
+}
+
+// ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
+func (v minoValidator) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) error {
+	logger := log.FromContext(ctx)
+	mino := newObj.(*Mino)
+	logger.Info("validate update", "name", mino.Name)
+
+	return v.validateMino(ctx, mino)
+}
+
+// ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
+func (v minoValidator) ValidateDelete(ctx context.Context, obj runtime.Object) error {
+	return nil
+}
+
+func (v minoValidator) validateMino(ctx context.Context, mino *Mino) error {
+	logger := log.FromContext(ctx)
+
+	err := validateMinoSpec(mino.Spec)
+	if err == nil {
+		err = v.validateUniqueMinoID(ctx, mino)
+	}
+	if err != nil {
+		logger.Error(err, "invalid Mino", "name", mino.Name)
+		return err
+	}
+	return nil
+}
+
+// validateUniqueMinoID checks that no other Mino in the namespace has the same MinoID.
+// Two Minoes with the same MinoID can still be accepted if they are created at the same time,
+// because neither of them exists when the other is validated.
+func (v minoValidator) validateUniqueMinoID(ctx context.Context, mino *Mino) error {
+	minoList := &MinoList{}
+	if err := v.reader.List(ctx, minoList, &client.ListOptions{Namespace: mino.Namespace}); err != nil {
+		return err
+	}
+	for _, m := range minoList.Items {
+		if m.Name != mino.Name && m.Spec.MinoID == mino.Spec.MinoID {
+			return fmt.Errorf("minoId %v is already used by Mino %v", mino.Spec.MinoID, m.Name)
+		}
+	}
+	return nil
+}
+
+func validateMinoSpec(spec MinoSpec) error {
+	if spec.MinoID <= 0 {
+		return fmt.Errorf("minoId must be greater than or equal to 1. minoId: %v", spec.MinoID)
+	}
+	if err := validateShape(spec.Coords); err != nil {
+		return fmt.Errorf("invalid coords: %w", err)
+	}
+	for i, coords := range spec.Rotations {
+		if err := validateShape(coords); err != nil {
+			return fmt.Errorf("invalid rotations[%d]: %w", i, err)
+		}
+	}
+	if !colorPattern.MatchString(spec.Color) {
+		return fmt.Errorf("invalid color: %q", spec.Color)
+	}
+	return nil
+}
+
+// validateShape checks that the coordinates are not empty, not duplicated and connected vertically or horizontally.
+func validateShape(coords []Coord) error {
+	if len(coords) == 0 {
+		return fmt.Errorf("no coordinates")
+	}
+	cells := make(map[Coord]bool)
+	for _, c := range coords {
+		if cells[c] {
+			return fmt.Errorf("duplicated coordinate: %v", c)
+		}
+		cells[c] = true
+	}
+
+	// Visit the cells connected to the first one
+	visited := map[Coord]bool{coords[0]: true}
+	queue := []Coord{coords[0]}
+	for len(queue) > 0 {
+		c := queue[0]
+		queue = queue[1:]
+		for _, n := range []Coord{{X: c.X + 1, Y: c.Y}, {X: c.X - 1, Y: c.Y}, {X: c.X, Y: c.Y + 1}, {X: c.X, Y: c.Y - 1}} {
+			if cells[n] && !visited[n] {
+				visited[n] = true
+				queue = append(queue, n)
+			}
+		}
+	}
+	if len(visited) != len(cells) {
+		return fmt.Errorf("coordinates are not connected")
+	}
+	return nil
+}
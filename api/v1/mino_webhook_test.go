@@ -0,0 +1,61 @@
+package v1
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var _ = Describe("Mino Webhook Test", func() {
+	ctx := context.Background()
+
+	newMino := func(name string, minoID int, coords []Coord, color string) *Mino {
+		return &Mino{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+			},
+			Spec: MinoSpec{
+				MinoID: minoID,
+				Coords: coords,
+				Color:  color,
+			},
+		}
+	}
+	tCoords := []Coord{{X: -1, Y: 0}, {X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 0}}
+
+	It("should not create a Mino with a duplicate minoId", func() {
+		By("creating the first Mino")
+		err := k8sClient.Create(ctx, newMino("mino-t", 7, tCoords, "#a59aca"))
+		Expect(err).ShouldNot(HaveOccurred())
+
+		By("creating the second Mino with the same minoId")
+		err = k8sClient.Create(ctx, newMino("mino-t2", 7, tCoords, "purple"))
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Error()).Should(ContainSubstring("minoId 7 is already used by Mino mino-t"))
+	})
+
+	It("should not create invalid Minoes", func() {
+		By("creating a Mino with minoId 0")
+		err := k8sClient.Create(ctx, newMino("mino-zero", 0, tCoords, "blue"))
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Error()).Should(ContainSubstring("minoId must be greater than or equal to 1"))
+
+		By("creating a Mino without coordinates")
+		err = k8sClient.Create(ctx, newMino("mino-empty", 10, nil, "blue"))
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Error()).Should(ContainSubstring("no coordinates"))
+
+		By("creating a Mino with disconnected coordinates")
+		err = k8sClient.Create(ctx, newMino("mino-disconnected", 11, []Coord{{X: 0, Y: 0}, {X: 2, Y: 0}}, "blue"))
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Error()).Should(ContainSubstring("coordinates are not connected"))
+
+		By("creating a Mino with an invalid color")
+		err = k8sClient.Create(ctx, newMino("mino-color", 12, tCoords, "#12345"))
+		Expect(err).Should(HaveOccurred())
+		Expect(err.Error()).Should(ContainSubstring("invalid color"))
+	})
+})
@@ -39,6 +39,68 @@ type T4sSpec struct {
 	//+kubebuilder:default=1000
 	Wait int `json:"wait,omitempty"`
 
+	// Speed curve of the game. Each entry overrides Wait from its level onwards, for instance [{level: 5, wait: 600}, {level: 10, wait: 300}]. This value is inherited by Board.
+	SpeedCurve []SpeedLevel `json:"speedCurve,omitempty"`
+
+	// Randomizer which decides the order of the minoes (default: Bag). Possible values are "Uniform" and "Bag". This value is inherited by Board.
+	//+kubebuilder:default="Bag"
+	Randomizer Randomizer `json:"randomizer,omitempty"`
+
+	// Number of the next minoes shown in advance (default: 3). This value is inherited by Board.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:validation:Maximum=6
+	//+kubebuilder:default=3
+	NextCount int `json:"nextCount,omitempty"`
+
+	// Lock delay in millisec, the grace period before the mino is fixed after it touches the ground (default: 500). This value is inherited by Board.
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:default=500
+	LockDelay int `json:"lockDelay,omitempty"`
+
+	// Maximum number of times the lock delay can be reset by moving or rotating a mino (default: 15). This value is inherited by Board.
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:default=15
+	MaxLockResets int `json:"maxLockResets,omitempty"`
+
+	// Game mode (default: Marathon). Possible values are "Marathon", "Sprint", "Ultra" and "Puzzle". This value is inherited by Board.
+	//+kubebuilder:default="Marathon"
+	Mode GameMode `json:"mode,omitempty"`
+
+	// Number of lines to clear in Sprint mode, or in Puzzle mode when puzzleGoal is "ClearLines" (default: 40). This value is inherited by Board.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:default=40
+	GoalLines int `json:"goalLines,omitempty"`
+
+	// Time limit of the game in seconds in Ultra mode (default: 120). This value is inherited by Board.
+	//+kubebuilder:validation:Minimum=1
+	//+kubebuilder:default=120
+	TimeLimitSeconds int `json:"timeLimitSeconds,omitempty"`
+
+	// Goal of Puzzle mode (default: ClearLines). Possible values are "ClearLines" and "ClearBoard". This value is inherited by Board.
+	//+kubebuilder:default="ClearLines"
+	PuzzleGoal PuzzleGoal `json:"puzzleGoal,omitempty"`
+
+	// Initial cells of the board from the top row to the bottom (0 is an empty cell). This value is inherited by Board.
+	InitialData [][]int `json:"initialData,omitempty"`
+
+	// Fixed sequence of the Mino IDs which appear in order. This value is inherited by Board.
+	Sequence []int `json:"sequence,omitempty"`
+
+	// Idle timeout in seconds (default: 600). The game is stopped when no Action is requested by the user for this period. 0 disables the idle timeout. This value is inherited by Board.
+	//+kubebuilder:validation:Minimum=0
+	//+kubebuilder:default=600
+	IdleTimeoutSeconds int `json:"idleTimeoutSeconds,omitempty"`
+
+	// State of the board after the idle timeout (default: Paused). Possible values are "Paused" and "GameOver". This value is inherited by Board.
+	//+kubebuilder:default="Paused"
+	IdleState IdleState `json:"idleState,omitempty"`
+
+	// IDs of the players in co-op mode, who play on the board at once with their own current minoes. This value is inherited by Board.
+	Players []string `json:"players,omitempty"`
+
+	// Names of the opponent T4s in the same namespace in versus mode. Lines cleared on this board are sent to their boards as garbage rows. This value is inherited by Board.
+	Opponents []string `json:"opponents,omitempty"`
+
 	// Type of the Service to which a user accesses to (default: NodePort). Supported values are "NodePort" and "LoadBalancer".
 	ServiceType string `json:"serviceType,omitempty"`
 
@@ -54,13 +116,48 @@ type T4sSpec struct {
 
 // T4sStatus defines the observed state of T4s.
 type T4sStatus struct {
+	// Conditions of the T4s. Supported types are "MinosReady", "BoardReady" and "AppAvailable".
+	//+listType=map
+	//+listMapKey=type
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+
+	// NodePort allocated to the service of the app
+	NodePort int32 `json:"nodePort,omitempty"`
+
+	// IP address or hostname of the load balancer allocated to the service of the app when serviceType is "LoadBalancer"
+	LoadBalancerAddress string `json:"loadBalancerAddress,omitempty"`
+
+	// URL to access the game. It is available only when the address of the load balancer is allocated.
+	URL string `json:"url,omitempty"`
+
+	// Current state of the Board. Possible values are "Playing", "Paused", "GameOver" and "Cleared".
+	BoardState BoardState `json:"boardState,omitempty"`
+
+	// Score of the current game
+	Score int `json:"score,omitempty"`
 }
 
+// Condition types of T4s
+const (
+	// The Minoes have been created from the built-in mino definitions.
+	T4sMinosReady = "MinosReady"
+	// The Board exists.
+	T4sBoardReady = "BoardReady"
+	// The deployment of the app is available.
+	T4sAppAvailable = "AppAvailable"
+)
+
 //+kubebuilder:object:root=true
 //+kubebuilder:subresource:status
 //+kubebuilder:printcolumn:name="WIDTH",type="integer",JSONPath=".spec.width"
 //+kubebuilder:printcolumn:name="HEIGHT",type="integer",JSONPath=".spec.height"
 //+kubebuilder:printcolumn:name="WAIT",type="integer",JSONPath=".spec.wait"
+//+kubebuilder:printcolumn:name="STATE",type="string",JSONPath=".status.boardState"
+//+kubebuilder:printcolumn:name="SCORE",type="integer",JSONPath=".status.score"
+//+kubebuilder:printcolumn:name="APP",type="string",JSONPath=`.status.conditions[?(@.type=="AppAvailable")].status`
+//+kubebuilder:printcolumn:name="NODEPORT",type="integer",JSONPath=".status.nodePort"
+//+kubebuilder:printcolumn:name="URL",type="string",JSONPath=".status.url"
+//+kubebuilder:printcolumn:name="AGE",type="date",JSONPath=".metadata.creationTimestamp"
 
 // T4s is the Schema for the T4s API.
 type T4s struct {
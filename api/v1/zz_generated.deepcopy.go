@@ -22,6 +22,7 @@ limitations under the License.
 package v1
 
 import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 )
 
@@ -31,7 +32,7 @@ func (in *Action) DeepCopyInto(out *Action) {
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	out.Spec = in.Spec
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Action.
@@ -102,6 +103,15 @@ func (in *ActionSpec) DeepCopy() *ActionSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ActionStatus) DeepCopyInto(out *ActionStatus) {
 	*out = *in
+	if in.ProcessedAt != nil {
+		in, out := &in.ProcessedAt, &out.ProcessedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Coords != nil {
+		in, out := &in.Coords, &out.Coords
+		*out = make([]Coord, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActionStatus.
@@ -114,12 +124,28 @@ func (in *ActionStatus) DeepCopy() *ActionStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AppliedAction) DeepCopyInto(out *AppliedAction) {
+	*out = *in
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AppliedAction.
+func (in *AppliedAction) DeepCopy() *AppliedAction {
+	if in == nil {
+		return nil
+	}
+	out := new(AppliedAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Board) DeepCopyInto(out *Board) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -176,6 +202,37 @@ func (in *BoardList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BoardSpec) DeepCopyInto(out *BoardSpec) {
 	*out = *in
+	if in.SpeedCurve != nil {
+		in, out := &in.SpeedCurve, &out.SpeedCurve
+		*out = make([]SpeedLevel, len(*in))
+		copy(*out, *in)
+	}
+	if in.InitialData != nil {
+		in, out := &in.InitialData, &out.InitialData
+		*out = make([][]int, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make([]int, len(*in))
+				copy(*out, *in)
+			}
+		}
+	}
+	if in.Sequence != nil {
+		in, out := &in.Sequence, &out.Sequence
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.Players != nil {
+		in, out := &in.Players, &out.Players
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Opponents != nil {
+		in, out := &in.Opponents, &out.Opponents
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BoardSpec.
@@ -209,6 +266,43 @@ func (in *BoardStatus) DeepCopyInto(out *BoardStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Next != nil {
+		in, out := &in.Next, &out.Next
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.Bag != nil {
+		in, out := &in.Bag, &out.Bag
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastUserActionTime != nil {
+		in, out := &in.LastUserActionTime, &out.LastUserActionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.AppliedActions != nil {
+		in, out := &in.AppliedActions, &out.AppliedActions
+		*out = make([]AppliedAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ElapsedTimeUpdatedAt != nil {
+		in, out := &in.ElapsedTimeUpdatedAt, &out.ElapsedTimeUpdatedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ClearedRows != nil {
+		in, out := &in.ClearedRows, &out.ClearedRows
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.GarbageReceived != nil {
+		in, out := &in.GarbageReceived, &out.GarbageReceived
+		*out = make(map[string]int, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BoardStatus.
@@ -330,6 +424,26 @@ func (in *CurrentMino) DeepCopyInto(out *CurrentMino) {
 	*out = in.DeepCopy()
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Kick) DeepCopyInto(out *Kick) {
+	*out = *in
+	if in.Offsets != nil {
+		in, out := &in.Offsets, &out.Offsets
+		*out = make([]Coord, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Kick.
+func (in *Kick) DeepCopy() *Kick {
+	if in == nil {
+		return nil
+	}
+	out := new(Kick)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Mino) DeepCopyInto(out *Mino) {
 	*out = *in
@@ -397,6 +511,24 @@ func (in *MinoSpec) DeepCopyInto(out *MinoSpec) {
 		*out = make([]Coord, len(*in))
 		copy(*out, *in)
 	}
+	if in.Kicks != nil {
+		in, out := &in.Kicks, &out.Kicks
+		*out = make([]Kick, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Rotations != nil {
+		in, out := &in.Rotations, &out.Rotations
+		*out = make([][]Coord, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make([]Coord, len(*in))
+				copy(*out, *in)
+			}
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MinoSpec.
@@ -424,13 +556,28 @@ func (in *MinoStatus) DeepCopy() *MinoStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SpeedLevel) DeepCopyInto(out *SpeedLevel) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpeedLevel.
+func (in *SpeedLevel) DeepCopy() *SpeedLevel {
+	if in == nil {
+		return nil
+	}
+	out := new(SpeedLevel)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *T4s) DeepCopyInto(out *T4s) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
 	in.Spec.DeepCopyInto(&out.Spec)
-	out.Status = in.Status
+	in.Status.DeepCopyInto(&out.Status)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new T4s.
@@ -486,6 +633,37 @@ func (in *T4sList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *T4sSpec) DeepCopyInto(out *T4sSpec) {
 	*out = *in
+	if in.SpeedCurve != nil {
+		in, out := &in.SpeedCurve, &out.SpeedCurve
+		*out = make([]SpeedLevel, len(*in))
+		copy(*out, *in)
+	}
+	if in.InitialData != nil {
+		in, out := &in.InitialData, &out.InitialData
+		*out = make([][]int, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make([]int, len(*in))
+				copy(*out, *in)
+			}
+		}
+	}
+	if in.Sequence != nil {
+		in, out := &in.Sequence, &out.Sequence
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
+	if in.Players != nil {
+		in, out := &in.Players, &out.Players
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Opponents != nil {
+		in, out := &in.Opponents, &out.Opponents
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 	if in.LoadBalancerSourceRanges != nil {
 		in, out := &in.LoadBalancerSourceRanges, &out.LoadBalancerSourceRanges
 		*out = make([]string, len(*in))
@@ -506,6 +684,13 @@ func (in *T4sSpec) DeepCopy() *T4sSpec {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *T4sStatus) DeepCopyInto(out *T4sStatus) {
 	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]metav1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new T4sStatus.
@@ -2,79 +2,169 @@ package main
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"sync"
 	"time"
 
 	t4sv1 "github.com/tkna/t4s/api/v1"
+	"github.com/tkna/t4s/pkg/constants"
 
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 	"k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
-	"k8s.io/apimachinery/pkg/types"
+	toolscache "k8s.io/client-go/tools/cache"
 	"k8s.io/utils/pointer"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/config"
 )
 
+// Color of the garbage rows sent by the opponents in versus mode
+const garbageColor = "#808080"
+
+// Interval of the comments sent to keep the event streams open through proxies
+const keepAliveInterval = 15 * time.Second
+
 type Board struct {
 	Width  int     `json:"width"`
 	Height int     `json:"height"`
 	Data   [][]int `json:"data"`
+	Score  int     `json:"score"`
+	Lines  int     `json:"lines"`
+	Level  int     `json:"level"`
+	Pieces int     `json:"pieces"`
+	Seed   int64   `json:"seed"`
+	Hold   *Mino   `json:"hold"`
+	Next   []Mino  `json:"next"`
+
+	// "Playing", "Paused", "GameOver" or "Cleared"
+	State t4sv1.BoardState `json:"state"`
+
+	// Reason why the game has been stopped, for instance "IdleTimeout"
+	StateReason string `json:"stateReason"`
+
+	// Play time in millisec, excluding the time paused
+	ElapsedTime int64 `json:"elapsedTime"`
+
+	// Landing position of the current mino in absolute coordinates
+	Ghost *Mino `json:"ghost"`
+
+	// Rows removed by the last fixed mino, which is identified by Pieces
+	ClearedRows []int `json:"clearedRows"`
+
+	// Type of the last line clear or T-spin, for instance "TSpinDouble"
+	LastClear t4sv1.ClearType `json:"lastClear"`
+
+	// Number of consecutive minoes which have cleared lines
+	Combo int `json:"combo"`
+
+	// Number of consecutive difficult line clears
+	BackToBack int `json:"backToBack"`
+
+	// Garbage rows sent by the opponents which have not come up yet in versus mode
+	PendingGarbage int `json:"pendingGarbage"`
+
+	// Name of the Board which has won the versus game
+	Winner string `json:"winner"`
+}
+
+type NewBoard struct {
+	Seed int64 `json:"seed"`
+}
+
+type Mino struct {
+	MinoID int           `json:"minoId"`
+	Coords []t4sv1.Coord `json:"coords"`
 }
 
 type Action struct {
 	Op string `json:"op"`
+
+	// ID of the player in co-op mode
+	Player string `json:"player,omitempty"`
+
+	// Name of the created Action, whose status shows the result
+	Name string `json:"name,omitempty"`
+}
+
+// boardHub delivers the changes of the Board to the event streams of the web clients.
+type boardHub struct {
+	mu          sync.Mutex
+	subscribers map[chan *t4sv1.Board]struct{}
+}
+
+// subscribe returns a channel which receives the latest Board whenever it changes.
+func (h *boardHub) subscribe() chan *t4sv1.Board {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	ch := make(chan *t4sv1.Board, 1)
+	h.subscribers[ch] = struct{}{}
+	return ch
+}
+
+func (h *boardHub) unsubscribe(ch chan *t4sv1.Board) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.subscribers, ch)
 }
 
+// publish sends the board to all the subscribers. A subscriber which has not received the previous board yet gets only the latest one.
+func (h *boardHub) publish(board *t4sv1.Board) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subscribers {
+		select {
+		case <-ch:
+		default:
+		}
+		ch <- board
+	}
+}
+
+// The variables are set up in init() and never modified afterwards, so that the handlers can read them concurrently.
+// The T4s and the Board are read from the cache in each request, because the Board is recreated for every new game.
+// Reader reads them from kube-apiserver directly where their UIDs are used, since the cache may still have the deleted ones.
 var (
-	Cli         client.Client
-	Namespace   string
-	T4sName     string
-	T4sUID      types.UID
-	BoardName   string
-	BoardUID    types.UID
-	TargetT4s   *t4sv1.T4s
-	TargetBoard *t4sv1.Board
+	Cli       client.Client
+	Reader    client.Reader
+	Hub       = &boardHub{subscribers: make(map[chan *t4sv1.Board]struct{})}
+	Namespace string
+	T4sName   string
+	BoardName string
 )
 
+// init sets up the client. The T4s and the Board are not required to exist at this point.
 func init() {
-	var err error
-	Cli, err = getClient()
-	if err != nil {
-		log.Fatal(err)
-	}
 	Namespace = os.Getenv("NAMESPACE")
 	T4sName = os.Getenv("T4S_NAME")
 	BoardName = os.Getenv("BOARD_NAME")
-
-	ctx := context.Background()
-	TargetT4s = &t4sv1.T4s{}
-	TargetBoard = &t4sv1.Board{}
-	if err := Cli.Get(ctx, client.ObjectKey{Namespace: Namespace, Name: T4sName}, TargetT4s); err != nil {
-		log.Fatal(err)
-	}
-	T4sUID = TargetT4s.GetUID()
-	err = Cli.Get(ctx, client.ObjectKey{Namespace: Namespace, Name: BoardName}, TargetBoard)
-	if errors.IsNotFound(err) {
-		log.Println("Board not found")
-		return
+	if BoardName == "" {
+		BoardName = constants.BoardName
 	}
+
+	var err error
+	Cli, Reader, err = getClient(context.Background())
 	if err != nil {
 		log.Fatal(err)
 	}
-	BoardUID = TargetBoard.GetUID()
 }
 
 func main() {
 	e := echo.New()
 	e.Static("/", "static")
 	e.GET("/board", getBoard)
+	e.GET("/board/events", streamBoard)
 	e.POST("/board", newBoard)
+	e.POST("/pause", pauseBoard)
+	e.POST("/resume", resumeBoard)
+	e.GET("/next", getNext)
 	e.GET("/colors", getColors)
 	e.GET("/wait", getWait)
 	e.POST("/actions", postAction, middleware.RateLimiter(
@@ -93,7 +183,8 @@ func main() {
 func getBoard(c echo.Context) error {
 	log.Println("getBoard")
 	ctx := context.Background()
-	err := Cli.Get(ctx, client.ObjectKey{Namespace: Namespace, Name: BoardName}, TargetBoard)
+	board := &t4sv1.Board{}
+	err := Cli.Get(ctx, client.ObjectKey{Namespace: Namespace, Name: BoardName}, board)
 	if errors.IsNotFound(err) {
 		log.Println("Board not found")
 		return c.NoContent(http.StatusOK)
@@ -103,48 +194,175 @@ func getBoard(c echo.Context) error {
 		return err
 	}
 
+	b, err := boardView(ctx, board, c.QueryParam("player"))
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return c.JSON(http.StatusOK, b)
+}
+
+// streamBoard sends the board to the web client as Server-Sent Events whenever it changes.
+// The current board is sent first. The ghost is shown for the player given by the query in co-op mode.
+func streamBoard(c echo.Context) error {
+	log.Println("streamBoard")
+	ctx := c.Request().Context()
+	player := c.QueryParam("player")
+	ch := Hub.subscribe()
+	defer Hub.unsubscribe(ch)
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.WriteHeader(http.StatusOK)
+
+	board := &t4sv1.Board{}
+	err := Cli.Get(ctx, client.ObjectKey{Namespace: Namespace, Name: BoardName}, board)
+	if err == nil {
+		if err := sendBoard(ctx, res, board, player); err != nil {
+			log.Println(err)
+			return nil
+		}
+	} else if !errors.IsNotFound(err) {
+		log.Println(err)
+		return nil
+	}
+
+	keepAlive := time.NewTicker(keepAliveInterval)
+	defer keepAlive.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			log.Println("event stream closed")
+			return nil
+		case board := <-ch:
+			if err := sendBoard(ctx, res, board, player); err != nil {
+				log.Println(err)
+				return nil
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(res, ": keep-alive\n\n"); err != nil {
+				log.Println(err)
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
+
+// sendBoard writes the board to the event stream.
+func sendBoard(ctx context.Context, res *echo.Response, board *t4sv1.Board, player string) error {
+	b, err := boardView(ctx, board, player)
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(b)
+	if err != nil {
+		return err
+	}
+	if _, err := fmt.Fprintf(res, "data: %s\n\n", data); err != nil {
+		return err
+	}
+	res.Flush()
+	return nil
+}
+
+// boardView returns the board shown to the player, with the current minoes painted on it.
+func boardView(ctx context.Context, board *t4sv1.Board, player string) (*Board, error) {
 	b := &Board{}
-	b.Width = TargetBoard.Spec.Width
-	b.Height = TargetBoard.Spec.Height
-	b.Data = TargetBoard.Status.Data
-	if len(TargetBoard.Status.CurrentMino) != 0 {
-		for _, coord := range TargetBoard.Status.CurrentMino[0].AbsoluteCoords {
-			b.Data[coord.Y][coord.X] = TargetBoard.Status.CurrentMino[0].MinoID
+	b.Width = board.Spec.Width
+	b.Height = board.Spec.Height
+	// The current minoes are painted on a copy, so that the board is left as it is
+	b.Data = make([][]int, len(board.Status.Data))
+	for y, row := range board.Status.Data {
+		b.Data[y] = append([]int{}, row...)
+	}
+	b.Score = board.Status.Score
+	b.Lines = board.Status.Lines
+	b.Level = board.Status.Level
+	b.Pieces = board.Status.Pieces
+	b.Seed = board.Status.Seed
+	b.ClearedRows = board.Status.ClearedRows
+	b.LastClear = board.Status.LastClear
+	b.Combo = board.Status.Combo
+	b.BackToBack = board.Status.BackToBack
+	b.PendingGarbage = board.Status.PendingGarbage
+	b.Winner = board.Status.Winner
+	b.State = board.Status.State
+	b.StateReason = board.Status.StateReason
+	b.ElapsedTime = board.Status.ElapsedTime
+	if board.Status.ElapsedTimeUpdatedAt != nil {
+		b.ElapsedTime += time.Since(board.Status.ElapsedTimeUpdatedAt.Time).Milliseconds()
+	}
+	coords, err := minoCoords(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if c, ok := coords[board.Status.HoldMino]; ok {
+		b.Hold = &Mino{MinoID: board.Status.HoldMino, Coords: c}
+	}
+	b.Next = nextMinoes(board, coords)
+	// The ghost is shown only for the mino of the player given by the query in co-op mode
+	for _, mino := range board.Status.CurrentMino {
+		if mino.Player == player {
+			b.Ghost = &Mino{MinoID: mino.MinoID, Coords: mino.GhostCoords}
+		}
+		for _, coord := range mino.AbsoluteCoords {
+			b.Data[coord.Y][coord.X] = mino.MinoID
 		}
 	}
-	return c.JSON(http.StatusOK, b)
+	return b, nil
 }
 
 func newBoard(c echo.Context) error {
 	log.Println("newBoard")
+	// Seed is optional to replay a game
+	req := new(NewBoard)
+	if err := c.Bind(req); err != nil {
+		log.Println(err)
+		return err
+	}
 	ctx := context.Background()
 
-	// Fetch latest T4s
-	if err := Cli.Get(ctx, client.ObjectKey{Namespace: Namespace, Name: T4sName}, TargetT4s); err != nil {
+	t4s := &t4sv1.T4s{}
+	if err := Reader.Get(ctx, client.ObjectKey{Namespace: Namespace, Name: T4sName}, t4s); err != nil {
 		log.Println(err)
 		return err
 	}
-	if !TargetT4s.ObjectMeta.DeletionTimestamp.IsZero() {
+	if !t4s.ObjectMeta.DeletionTimestamp.IsZero() {
 		log.Println("T4s is being deleted")
 		return nil
 	}
-	T4sUID = TargetT4s.GetUID()
 
 	// Delete the existing board
-	if !TargetBoard.ObjectMeta.DeletionTimestamp.IsZero() {
-		log.Println("Board is being deleted")
-		return nil
-	}
-	err := Cli.Delete(ctx, TargetBoard)
-	if errors.IsNotFound(err) {
+	current := &t4sv1.Board{}
+	err := Reader.Get(ctx, client.ObjectKey{Namespace: Namespace, Name: BoardName}, current)
+	switch {
+	case errors.IsNotFound(err):
 		log.Println("Board not found")
-	} else if err != nil {
+	case err != nil:
 		log.Println(err)
 		return err
+	case !current.ObjectMeta.DeletionTimestamp.IsZero():
+		log.Println("Board is being deleted")
+		return nil
+	default:
+		// The precondition keeps a Board created by another request at the same time from being deleted
+		uid := current.GetUID()
+		err := Cli.Delete(ctx, current, client.Preconditions{UID: &uid})
+		if errors.IsConflict(err) {
+			log.Println("Board has been recreated")
+			return c.NoContent(http.StatusConflict)
+		}
+		if err != nil && !errors.IsNotFound(err) {
+			log.Println(err)
+			return err
+		}
 	}
 
 	// Create a new board
-	TargetBoard = &t4sv1.Board{
+	board := &t4sv1.Board{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace: Namespace,
 			Name:      BoardName,
@@ -153,29 +371,86 @@ func newBoard(c echo.Context) error {
 					APIVersion:         "t4s.tkna.net/v1",
 					Kind:               "T4s",
 					Name:               T4sName,
-					UID:                T4sUID,
+					UID:                t4s.GetUID(),
 					Controller:         pointer.Bool(true),
 					BlockOwnerDeletion: pointer.Bool(true),
 				},
 			},
 		},
 		Spec: t4sv1.BoardSpec{
-			Width:  TargetT4s.Spec.Width,
-			Height: TargetT4s.Spec.Height,
-			Wait:   TargetT4s.Spec.Wait,
-			State:  t4sv1.Playing,
+			Width:              t4s.Spec.Width,
+			Height:             t4s.Spec.Height,
+			Wait:               t4s.Spec.Wait,
+			SpeedCurve:         t4s.Spec.SpeedCurve,
+			Randomizer:         t4s.Spec.Randomizer,
+			NextCount:          t4s.Spec.NextCount,
+			LockDelay:          t4s.Spec.LockDelay,
+			MaxLockResets:      t4s.Spec.MaxLockResets,
+			Mode:               t4s.Spec.Mode,
+			GoalLines:          t4s.Spec.GoalLines,
+			TimeLimitSeconds:   t4s.Spec.TimeLimitSeconds,
+			PuzzleGoal:         t4s.Spec.PuzzleGoal,
+			InitialData:        t4s.Spec.InitialData,
+			Sequence:           t4s.Spec.Sequence,
+			IdleTimeoutSeconds: t4s.Spec.IdleTimeoutSeconds,
+			IdleState:          t4s.Spec.IdleState,
+			Players:            t4s.Spec.Players,
+			Opponents:          t4s.Spec.Opponents,
+			Seed:               req.Seed,
+			State:              t4sv1.Playing,
 		},
 	}
-	if err := Cli.Create(ctx, TargetBoard); err != nil {
+	err = Cli.Create(ctx, board)
+	if errors.IsAlreadyExists(err) {
+		log.Println("Board already exists")
+		return c.NoContent(http.StatusConflict)
+	}
+	if err != nil {
 		log.Println(err)
 		return err
 	}
-	BoardUID = TargetBoard.GetUID()
 
 	log.Println("new board created")
 	return c.NoContent(http.StatusOK)
 }
 
+func pauseBoard(c echo.Context) error {
+	log.Println("pauseBoard")
+	return setBoardState(c, t4sv1.Paused)
+}
+
+func resumeBoard(c echo.Context) error {
+	log.Println("resumeBoard")
+	return setBoardState(c, t4sv1.Playing)
+}
+
+// setBoardState sets the desired state of the board to pause or resume the game.
+func setBoardState(c echo.Context, state t4sv1.BoardState) error {
+	ctx := context.Background()
+	board := &t4sv1.Board{}
+	err := Cli.Get(ctx, client.ObjectKey{Namespace: Namespace, Name: BoardName}, board)
+	if errors.IsNotFound(err) {
+		log.Println("Board not found")
+		return c.NoContent(http.StatusNotFound)
+	}
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	if board.Status.State == t4sv1.GameOver || board.Status.State == t4sv1.Cleared {
+		log.Println("the game is finished")
+		return c.NoContent(http.StatusConflict)
+	}
+
+	patch := client.MergeFrom(board.DeepCopy())
+	board.Spec.State = state
+	if err := Cli.Patch(ctx, board, patch); err != nil {
+		log.Println(err)
+		return err
+	}
+	return c.NoContent(http.StatusOK)
+}
+
 func postAction(c echo.Context) error {
 	log.Println("postAction")
 	action := new(Action)
@@ -185,32 +460,94 @@ func postAction(c echo.Context) error {
 	}
 
 	ctx := context.Background()
+	// The UID is resolved for each Action, since the Board is recreated for every new game
+	board := &t4sv1.Board{}
+	err := Reader.Get(ctx, client.ObjectKey{Namespace: Namespace, Name: BoardName}, board)
+	if errors.IsNotFound(err) {
+		log.Println("Board not found")
+		return c.NoContent(http.StatusNotFound)
+	}
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
 	ac := t4sv1.Action{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace:    Namespace,
 			GenerateName: "action-",
+			Labels: map[string]string{
+				constants.ActionBoardLabel: BoardName,
+			},
 			OwnerReferences: []metav1.OwnerReference{
 				{
 					APIVersion:         "t4s.tkna.net/v1",
 					Kind:               "Board",
 					Name:               BoardName,
-					UID:                BoardUID,
+					UID:                board.GetUID(),
 					Controller:         pointer.Bool(true),
 					BlockOwnerDeletion: pointer.Bool(true),
 				},
 			},
 		},
 		Spec: t4sv1.ActionSpec{
-			Op: action.Op,
+			Op:       action.Op,
+			Player:   action.Player,
+			Sequence: t4sv1.NewActionSequence(),
 		},
 	}
 	if err := Cli.Create(ctx, &ac); err != nil {
 		log.Println(err)
 		return err
 	}
+	action.Name = ac.GetName()
 	return c.JSON(http.StatusOK, action)
 }
 
+func getNext(c echo.Context) error {
+	log.Println("getNext")
+	ctx := context.Background()
+	board := &t4sv1.Board{}
+	err := Cli.Get(ctx, client.ObjectKey{Namespace: Namespace, Name: BoardName}, board)
+	if errors.IsNotFound(err) {
+		log.Println("Board not found")
+		return c.NoContent(http.StatusOK)
+	}
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+
+	coords, err := minoCoords(ctx)
+	if err != nil {
+		log.Println(err)
+		return err
+	}
+	return c.JSON(http.StatusOK, nextMinoes(board, coords))
+}
+
+// minoCoords returns the coordinates of the Minoes by MinoID.
+func minoCoords(ctx context.Context) (map[int][]t4sv1.Coord, error) {
+	minoList := t4sv1.MinoList{}
+	if err := Cli.List(ctx, &minoList, &client.ListOptions{Namespace: Namespace}); err != nil {
+		return nil, err
+	}
+	coords := make(map[int][]t4sv1.Coord)
+	for _, mino := range minoList.Items {
+		coords[mino.Spec.MinoID] = mino.Spec.Coords
+	}
+	return coords, nil
+}
+
+// nextMinoes returns the minoes in the next queue of the board.
+func nextMinoes(board *t4sv1.Board, coords map[int][]t4sv1.Coord) []Mino {
+	next := make([]Mino, len(board.Status.Next))
+	for i, id := range board.Status.Next {
+		next[i] = Mino{MinoID: id, Coords: coords[id]}
+	}
+	return next
+}
+
 func getColors(c echo.Context) error {
 	log.Println("getColors")
 	ctx := context.Background()
@@ -220,13 +557,14 @@ func getColors(c echo.Context) error {
 		return err
 	}
 
-	cls := make([][]interface{}, len(minoList.Items))
+	cls := make([][]interface{}, len(minoList.Items), len(minoList.Items)+1)
 	for i, mino := range minoList.Items {
 		var cl = make([]interface{}, 2)
 		cl[0] = mino.Spec.MinoID
 		cl[1] = mino.Spec.Color
 		cls[i] = cl
 	}
+	cls = append(cls, []interface{}{t4sv1.GarbageCell, garbageColor})
 
 	return c.JSON(http.StatusOK, cls)
 }
@@ -234,27 +572,75 @@ func getColors(c echo.Context) error {
 func getWait(c echo.Context) error {
 	log.Println("getWait")
 	ctx := context.Background()
-	// Fetch latest T4s
-	if err := Cli.Get(ctx, client.ObjectKey{Namespace: Namespace, Name: T4sName}, TargetT4s); err != nil {
+	t4s := &t4sv1.T4s{}
+	if err := Cli.Get(ctx, client.ObjectKey{Namespace: Namespace, Name: T4sName}, t4s); err != nil {
 		log.Println(err)
 		return err
 	}
-	T4sUID = TargetT4s.GetUID()
-	return c.JSON(http.StatusOK, TargetT4s.Spec.Wait)
+
+	// The wait time gets shorter as the level goes up
+	board := &t4sv1.Board{}
+	err := Cli.Get(ctx, client.ObjectKey{Namespace: Namespace, Name: BoardName}, board)
+	if err != nil && !errors.IsNotFound(err) {
+		log.Println(err)
+		return err
+	}
+	if board.Status.Wait != 0 {
+		return c.JSON(http.StatusOK, board.Status.Wait)
+	}
+	return c.JSON(http.StatusOK, t4s.Spec.Wait)
 }
 
-func getClient() (client.Client, error) {
+// getClient returns the client which reads the resources from the cache of the informers.
+// The changes of the Board are published to Hub.
+func getClient(ctx context.Context) (client.Client, client.Reader, error) {
 	cfg, err := config.GetConfig()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	scm := runtime.NewScheme()
 	if err := t4sv1.AddToScheme(scm); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 	cli, err := client.New(cfg, client.Options{Scheme: scm})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	c, err := cache.New(cfg, cache.Options{Scheme: scm, Namespace: Namespace})
+	if err != nil {
+		return nil, nil, err
+	}
+	informer, err := c.GetInformer(ctx, &t4sv1.Board{})
+	if err != nil {
+		return nil, nil, err
+	}
+	informer.AddEventHandler(toolscache.ResourceEventHandlerFuncs{
+		AddFunc:    publishBoard,
+		UpdateFunc: func(_, obj interface{}) { publishBoard(obj) },
+	})
+	go func() {
+		if err := c.Start(ctx); err != nil {
+			log.Fatal(err)
+		}
+	}()
+	if !c.WaitForCacheSync(ctx) {
+		return nil, nil, fmt.Errorf("failed to sync the cache")
+	}
+
+	delegating, err := client.NewDelegatingClient(client.NewDelegatingClientInput{CacheReader: c, Client: cli})
+	if err != nil {
+		return nil, nil, err
+	}
+	return delegating, cli, nil
+}
+
+// publishBoard publishes the Board of the app to Hub. The other Boards in the namespace are ignored.
+func publishBoard(obj interface{}) {
+	board, ok := obj.(*t4sv1.Board)
+	if !ok || board.Name != BoardName {
+		return
 	}
-	return cli, nil
+	// The object in the cache must not be modified
+	Hub.publish(board.DeepCopy())
 }
@@ -1,30 +1,23 @@
 const BLOCK_SIZE = 20;
 const WALL_SIZE = 2;
 var colorMap;
-var wait;
-var intervalId;
+var flashedPieces;
+var boardState;
+// ID of the player in co-op mode, given by the query like "?player=p1"
+const player = new URLSearchParams(location.search).get("player") || "";
 
 async function init() {
   await fetchColorMap();
-  setInterval(fetchWait, 1000);
+  watchBoard();
 }
 
-async function fetchWait() {
-  var newWait;
-  await fetch('/wait')
-    .then((response) => response.json())
-    .then((json) => {
-            newWait = json;
-    });
-  if (newWait != wait) {
-    console.log("newWait detected", "wait:", wait, "newWait:", newWait)
-    if (intervalId !== undefined) {
-      console.log("clearing existing interval")
-      clearInterval(intervalId);
-    }
-    intervalId = setInterval(fetchBoard, newWait);
-    wait = newWait;
-  }
+// watchBoard draws the board pushed by the server whenever it changes.
+// EventSource reconnects by itself when the connection is lost.
+function watchBoard() {
+  const source = new EventSource('/board/events?player=' + encodeURIComponent(player));
+  source.onmessage = (event) => {
+    draw(JSON.parse(event.data));
+  };
 }
 
 async function fetchColorMap() {
@@ -36,16 +29,8 @@ async function fetchColorMap() {
                 });
 }
 
-async function fetchBoard() {
-        return fetch('/board')
-                .then((response) => response.json())
-                .then((board) => {
-                        draw(board);
-                });
-}
-
 async function move(op) {
-  const data = { "op": op };
+  const data = { "op": op, "player": player };
   console.log(data);
   const param = {
     method: "POST",
@@ -55,12 +40,7 @@ async function move(op) {
     body: JSON.stringify(data)
   };
 
-  return fetch('/actions', param)
-    .then((response) => {
-      if (response.ok) {
-        fetchBoard();
-      } 
-    });
+  return fetch('/actions', param);
 }
 
 document.addEventListener('keydown', (event) => {
@@ -84,6 +64,26 @@ document.addEventListener('keydown', (event) => {
     case ' ':
       move('drop');
       event.preventDefault();
+      break;
+    case 'c':
+      move('hold');
+      event.preventDefault();
+      break;
+    case 'x':
+      move('rotate');
+      event.preventDefault();
+      break;
+    case 'z':
+      move('rotateCCW');
+      event.preventDefault();
+      break;
+    case 'a':
+      move('rotate180');
+      event.preventDefault();
+      break;
+    case 'p':
+      togglePause();
+      event.preventDefault();
       break;
 	}
 });
@@ -99,7 +99,88 @@ function newGame() {
   return fetch('/board', param);
 }
 
+// togglePause pauses the game being played, or resumes the paused game.
+function togglePause() {
+  var path;
+  if (boardState == "Playing") {
+    path = '/pause';
+  } else if (boardState == "Paused") {
+    path = '/resume';
+  } else {
+    return;
+  }
+  const param = {
+    method: "POST",
+    headers: {
+      "Content-Type": "application/json; charset=utf-8"
+    }
+  };
+  return fetch(path, param);
+}
+
+// formatTime formats the time in millisec as "m:ss".
+function formatTime(ms) {
+  const sec = Math.floor((ms || 0) / 1000);
+  return Math.floor(sec / 60) + ":" + String(sec % 60).padStart(2, "0");
+}
+
+function drawStats(json) {
+  document.getElementById("score").textContent = json.score;
+  document.getElementById("level").textContent = json.level;
+  document.getElementById("lines").textContent = json.lines;
+  document.getElementById("time").textContent = formatTime(json.elapsedTime);
+  var state = "";
+  if (json.state == "Paused") {
+    state = "PAUSED";
+  } else if (json.state == "Cleared") {
+    state = "CLEARED";
+  }
+  if (json.stateReason == "IdleTimeout") {
+    state += " (IDLE)";
+  } else if (json.stateReason == "Won") {
+    state = "WIN";
+  } else if (json.state == "GameOver" && json.winner) {
+    state = "LOSE";
+  }
+  if (json.pendingGarbage > 0) {
+    state += " GARBAGE " + json.pendingGarbage;
+  }
+  document.getElementById("state").textContent = state;
+  var clear = json.lastClear ? json.lastClear.replace("TSpin", "T-Spin").replace(/([a-z])([A-Z])/g, "$1 $2").toUpperCase() : "";
+  if (json.backToBack > 1) {
+    clear = "B2B " + clear;
+  }
+  if (json.combo > 1) {
+    clear += " COMBO " + (json.combo - 1);
+  }
+  document.getElementById("clear").textContent = clear;
+  boardState = json.state;
+}
+
+// drawPreview draws the minoes side by side in a small canvas.
+function drawPreview(id, minoes) {
+  const SLOT_SIZE = 5;
+  var canvas = document.getElementById(id);
+  canvas.setAttribute("width", String(SLOT_SIZE * BLOCK_SIZE));
+  canvas.setAttribute("height", String(minoes.length * SLOT_SIZE * BLOCK_SIZE));
+  var ctx = canvas.getContext("2d");
+
+  for (let i = 0; i < minoes.length; i++) {
+    ctx.fillStyle = colorMap.get(minoes[i].minoId);
+    for (const coord of minoes[i].coords) {
+      // zero values are omitted in the JSON
+      const x = 1 + (coord.x || 0);
+      const y = i * SLOT_SIZE + 2 - (coord.y || 0);
+      ctx.fillRect(x * BLOCK_SIZE + 2, y * BLOCK_SIZE + 2, BLOCK_SIZE - 1, BLOCK_SIZE - 1);
+    }
+  }
+}
+
 function draw(json) {
+  drawStats(json);
+  drawPreview("hold", json.hold ? [json.hold] : []);
+  drawPreview("next", json.next);
+
   var canvas = document.getElementById("stage");
   canvas.setAttribute("width", String(json.width * BLOCK_SIZE + WALL_SIZE*2));
   canvas.setAttribute("height", String(json.height * BLOCK_SIZE + WALL_SIZE));
@@ -129,6 +210,20 @@ function draw(json) {
   ctx.stroke();
   ctx.closePath();
 
+  // draw the ghost of the current mino
+  if (json.ghost) {
+    ctx.strokeStyle = colorMap.get(json.ghost.minoId);
+    ctx.lineWidth = 2;
+    for (const coord of json.ghost.coords) {
+      // zero values are omitted in the JSON
+      const x = coord.x || 0;
+      const y = coord.y || 0;
+      if (json.data[y][x] == 0) {
+        ctx.strokeRect(WALL_SIZE + x * BLOCK_SIZE + 3, y * BLOCK_SIZE + 3, BLOCK_SIZE - 4, BLOCK_SIZE - 4);
+      }
+    }
+  }
+
   // draw the board
   for (let i = 0; i < json.height; i++) {
     for (let j = 0; j < json.width; j++) {
@@ -146,6 +241,15 @@ function draw(json) {
       }
     }
   }
+
+  // flash the rows removed by the mino which has just been fixed
+  if (json.clearedRows && json.pieces !== flashedPieces) {
+    ctx.fillStyle = "rgba(255, 255, 255, 0.7)";
+    for (const y of json.clearedRows) {
+      ctx.fillRect(WALL_SIZE, y * BLOCK_SIZE, json.width * BLOCK_SIZE, BLOCK_SIZE);
+    }
+    flashedPieces = json.pieces;
+  }
 }
 
 init();
@@ -19,9 +19,11 @@ package controllers
 import (
 	"context"
 	"fmt"
-	"math/rand"
+	"sort"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
@@ -32,18 +34,32 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	t4sv1 "github.com/tkna/t4s/api/v1"
+	"github.com/tkna/t4s/pkg/constants"
+	"github.com/tkna/t4s/pkg/engine"
 )
 
 // BoardReconciler reconciles a Board object.
 type BoardReconciler struct {
 	client.Client
 	Scheme *runtime.Scheme
+
+	// MaxActionsPerReconcile is the maximum number of Actions applied in a reconciliation.
+	// If it is not positive, DefaultMaxActionsPerReconcile is used.
+	MaxActionsPerReconcile int
+
+	// ActionTTL is how long the processed Actions are kept so that their results can be observed.
+	// If it is not positive, the Actions are deleted as soon as they are processed.
+	ActionTTL time.Duration
 }
 
+// DefaultMaxActionsPerReconcile is the default maximum number of Actions applied in a reconciliation.
+const DefaultMaxActionsPerReconcile = 20
+
 //+kubebuilder:rbac:groups=t4s.tkna.net,resources=boards,verbs=get;list;watch;create;update;patch;delete
 //+kubebuilder:rbac:groups=t4s.tkna.net,resources=boards/status,verbs=get;update;patch
 //+kubebuilder:rbac:groups=t4s.tkna.net,resources=boards/finalizers,verbs=update
 //+kubebuilder:rbac:groups=t4s.tkna.net,resources=actions,verbs=get;list;watch;create;update;patch;delete
+//+kubebuilder:rbac:groups=t4s.tkna.net,resources=actions/status,verbs=get;update;patch
 
 func (r *BoardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
 	logger := log.FromContext(ctx)
@@ -53,7 +69,10 @@ func (r *BoardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 	err := r.Get(ctx, req.NamespacedName, &board)
 	if errors.IsNotFound(err) {
 		logger.Error(err, "Board not found", "name", req.NamespacedName)
-		RemovedRowsVec.DeleteLabelValues(req.NamespacedName.Namespace)
+		RemovedRowsVec.DeleteLabelValues(req.Namespace, req.Name)
+		for _, clear := range engine.ClearTypes() {
+			LineClearsVec.DeleteLabelValues(req.Namespace, req.Name, string(clear))
+		}
 		return ctrl.Result{}, nil
 	}
 	if err != nil {
@@ -70,60 +89,99 @@ func (r *BoardReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl
 		for i := 0; i < board.Spec.Height; i++ {
 			board.Status.Data[i] = make([]int, board.Spec.Width)
 		}
+		for y, row := range board.Spec.InitialData {
+			if y < board.Spec.Height {
+				copy(board.Status.Data[y], row)
+			}
+		}
 		board.Status.State = board.Spec.State
+		board.Status.Level = 1
 	}
+	if board.Status.Seed == 0 {
+		initSeed(&board)
+	}
+
+	reconcileState(ctx, &board)
 
-	if err := r.reconcileCurrentMino(ctx, &board); err != nil {
+	var minoes []t4sv1.Mino
+	if board.Status.State != t4sv1.GameOver {
+		minoes, err = r.listMinoes(ctx, &board)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	reconcileCurrentMino(ctx, &board, minoes)
+
+	applied, pending, expireAfter, err := r.reconcileAction(ctx, &board, minoes)
+	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	if err := r.reconcileAction(ctx, &board); err != nil {
+	now := time.Now()
+	requeueAfter := shorterRequeue(expireAfter, reconcileLockDelay(ctx, &board))
+	requeueAfter = shorterRequeue(requeueAfter, reconcileIdleTimeout(ctx, &board, now))
+
+	// The clock and the goal are checked once the Actions are applied
+	reconcileElapsedTime(&board, now)
+	requeueAfter = shorterRequeue(requeueAfter, reconcileGoal(ctx, &board))
+
+	versusRequeue, err := r.reconcileVersus(ctx, &board)
+	if err != nil {
 		return ctrl.Result{}, err
 	}
+	requeueAfter = shorterRequeue(requeueAfter, versusRequeue)
+
+	game := newGame(&board, nil)
+	for i := range board.Status.CurrentMino {
+		board.Status.CurrentMino[i].GhostCoords = boardCoords(game.Ghost(i).Cells())
+	}
+
+	board.Status.Wait = currentWait(&board)
 
 	if err := r.reconcileCron(ctx, &board); err != nil {
 		return ctrl.Result{}, err
 	}
 
+	// Record the pause in the spec too, so that the game is resumed when the spec is set back to Playing.
+	// The spec is patched before the status is saved, otherwise the game would be resumed by the spec left Playing.
+	if isIdlePaused(&board) && board.Spec.State != t4sv1.Paused {
+		paused := board.DeepCopy()
+		paused.Spec.State = t4sv1.Paused
+		if err := r.Patch(ctx, paused, client.MergeFrom(&board)); err != nil {
+			logger.Error(err, "failed to pause board")
+			return ctrl.Result{}, err
+		}
+		board.Spec.State = t4sv1.Paused
+		board.ResourceVersion = paused.ResourceVersion
+	}
+
 	if err := r.Status().Update(ctx, &board); err != nil {
 		logger.Error(err, "failed to update board")
 		return ctrl.Result{}, err
 	}
 
-	logger.Info("reconcile Board successfully")
-	return ctrl.Result{}, nil
-}
-
-func isCollision(board t4sv1.Board, coords []t4sv1.Coord) bool {
-	for _, coord := range coords {
-		if coord.X < 0 || coord.X >= board.Spec.Width {
-			return true
-		}
-		if coord.Y < 0 || coord.Y >= board.Spec.Height {
-			return true
-		}
-		if board.Status.Data[coord.Y][coord.X] != 0 {
-			return true
-		}
+	// The Actions are finished only after the board is saved, so that they are applied again if the update fails.
+	// The board records them in AppliedActions, so that they are not applied again if finishing them fails.
+	if err := r.finishActions(ctx, applied); err != nil {
+		return ctrl.Result{}, err
 	}
-	return false
+
+	logger.Info("reconcile Board successfully")
+	return ctrl.Result{Requeue: pending, RequeueAfter: requeueAfter}, nil
 }
 
-func setAbsoluteCoords(mino *t4sv1.CurrentMino) {
-	var coords []t4sv1.Coord
-	for _, coord := range mino.RelativeCoords {
-		c := t4sv1.Coord{
-			X: mino.Center.X + coord.X,
-			Y: mino.Center.Y - coord.Y,
-		}
-		coords = append(coords, c)
+// shorterRequeue returns the shorter one of the durations to requeue. 0 means no requeue.
+func shorterRequeue(a, b time.Duration) time.Duration {
+	if a == 0 || (b > 0 && b < a) {
+		return b
 	}
-	mino.AbsoluteCoords = coords
+	return a
 }
 
-func (r *BoardReconciler) newMino(ctx context.Context, board *t4sv1.Board) (bool, error) {
+// listMinoes lists the Minoes in the namespace of the board in the order of MinoID.
+func (r *BoardReconciler) listMinoes(ctx context.Context, board *t4sv1.Board) ([]t4sv1.Mino, error) {
 	logger := log.FromContext(ctx)
-	logger.Info("newMino")
 
 	logger.Info("list Minoes")
 	minoes := t4sv1.MinoList{}
@@ -132,211 +190,283 @@ func (r *BoardReconciler) newMino(ctx context.Context, board *t4sv1.Board) (bool
 	})
 	if err != nil {
 		logger.Error(err, "failed to list Minoes")
-		return false, err
+		return nil, err
 	}
 	if len(minoes.Items) == 0 {
 		logger.Info("no minoes found")
-		return false, fmt.Errorf("no minoes found")
-	}
-
-	selectedMino := minoes.Items[rand.Intn(len(minoes.Items))]
-	mino := t4sv1.CurrentMino{
-		MinoID:         selectedMino.Spec.MinoID,
-		Center:         t4sv1.Coord{X: (board.Spec.Width - 1) / 2, Y: 2},
-		RelativeCoords: append([]t4sv1.Coord{}, selectedMino.Spec.Coords...),
-	}
-	setAbsoluteCoords(&mino)
-	if isCollision(*board, mino.AbsoluteCoords) {
-		return false, nil
+		return nil, fmt.Errorf("no minoes found")
 	}
-	board.Status.CurrentMino = []t4sv1.CurrentMino{}
-	board.Status.CurrentMino = append(board.Status.CurrentMino, mino)
 
-	return true, nil
+	sort.Slice(minoes.Items, func(i, j int) bool {
+		return minoes.Items[i].Spec.MinoID < minoes.Items[j].Spec.MinoID
+	})
+	return minoes.Items, nil
 }
 
-func (r *BoardReconciler) reconcileCurrentMino(ctx context.Context, board *t4sv1.Board) error {
+// reconcileCurrentMino puts a new mino for each player who has no current mino, and removes the minoes of the players who have left.
+// When the minoes in the sequence run out, the game is over after the current minoes of all the players have landed.
+func reconcileCurrentMino(ctx context.Context, board *t4sv1.Board, minoes []t4sv1.Mino) {
 	logger := log.FromContext(ctx)
 	logger.Info("reconcile CurrentMino")
 
-	if board.Status.State == t4sv1.GameOver {
-		logger.Info("State == GameOver")
-		return nil
+	if board.Status.State != t4sv1.Playing {
+		logger.Info("State != Playing", "state", board.Status.State)
+		return
 	}
 
-	if len(board.Status.CurrentMino) == 0 {
-		logger.Info("no current mino. creating")
-		ok, err := r.newMino(ctx, board)
-		if err != nil {
-			return err
-		}
-		if !ok {
-			logger.Info("failed to create a new mino. game over")
-			board.Status.State = t4sv1.GameOver
-		}
+	game := newGame(board, minoes)
+	game.Deal()
+	setGame(board, game)
+	if game.End != engine.NotOver {
+		logger.Info("failed to create a new mino. game over", "reason", game.End)
+		return
 	}
 
 	logger.Info("reconcile CurrentMino successfully")
-	return nil
 }
 
-func moveCurrentMino(ctx context.Context, board *t4sv1.Board, op string) {
+// holdMino swaps the i-th current mino with the held one, or holds it and takes the next mino if nothing is held.
+// The hold can be used only once until the current mino lands.
+func holdMino(ctx context.Context, board *t4sv1.Board, minoes []t4sv1.Mino, i int) t4sv1.ActionResult {
 	logger := log.FromContext(ctx)
-	logger.Info("move current mino", "op", op)
+	logger.Info("hold current mino")
+
+	game := newGame(board, minoes)
+	result := game.Hold(i)
+	setGame(board, game)
+	if game.End != engine.NotOver {
+		logger.Info("failed to take out a mino. game over")
+	} else if result == engine.ResultApplied {
+		logger.Info("hold current mino successfully")
+	}
+	return t4sv1.ActionResult(result)
+}
 
-	mino := board.Status.CurrentMino[0].DeepCopy()
+// moveCurrentMino applies the op to the i-th current mino.
+// The next mino appears at once if the mino is dropped, unless the goal is reached.
+func moveCurrentMino(ctx context.Context, board *t4sv1.Board, minoes []t4sv1.Mino, i int, op string, byUser bool) t4sv1.ActionResult {
+	logger := log.FromContext(ctx)
+	logger.Info("move current mino", "op", op, "byUser", byUser, "player", board.Status.CurrentMino[i].Player)
+
+	game := newGame(board, minoes)
+	result, landing := game.Apply(i, op, byUser, time.Now())
+	setGame(board, game)
+	if result != engine.ResultApplied {
+		logger.Info("CurrentMino is not moved", "result", result)
+		return t4sv1.ActionResult(result)
+	}
+	if landing != nil {
+		recordLanding(ctx, board, *landing)
+	}
+	if op == "drop" {
+		reconcileCurrentMino(ctx, board, minoes)
+	}
 
-	switch op {
-	case "down":
-		mino.Center.Y++
-		setAbsoluteCoords(&mino)
-		if isCollision(*board, mino.AbsoluteCoords) {
-			for _, coord := range board.Status.CurrentMino[0].AbsoluteCoords {
-				board.Status.Data[coord.Y][coord.X] = board.Status.CurrentMino[0].MinoID
-			}
-			checkRemoveRows(ctx, board)
-			board.Status.CurrentMino = nil
-			logger.Info("CurrentMino landed successfully")
-		} else {
-			board.Status.CurrentMino[0] = mino
-		}
+	logger.Info("move CurrentMino successfully")
+	return t4sv1.ActionApplied
+}
 
-	case "left":
-		mino.Center.X--
-		setAbsoluteCoords(&mino)
-		if isCollision(*board, mino.AbsoluteCoords) {
-			return
+// findCurrentMino returns the index of the current mino of the player, or -1 if the player has none.
+func findCurrentMino(board *t4sv1.Board, player string) int {
+	for i, mino := range board.Status.CurrentMino {
+		if mino.Player == player {
+			return i
 		}
-		board.Status.CurrentMino[0] = mino
+	}
+	return -1
+}
 
-	case "right":
-		mino.Center.X++
-		setAbsoluteCoords(&mino)
-		if isCollision(*board, mino.AbsoluteCoords) {
-			return
-		}
-		board.Status.CurrentMino[0] = mino
+// reconcileAction applies the pending Actions for the board in the order they are requested, and returns them with the results in their status.
+// The Actions recorded in board.Status.AppliedActions are returned again without being applied.
+// They must be passed to finishActions after the board is saved. Actions are bound to the board by the label constants.ActionBoardLabel.
+// It also returns true if some Actions are left pending because of MaxActionsPerReconcile,
+// and the time left until the next processed Action expires.
+func (r *BoardReconciler) reconcileAction(ctx context.Context, board *t4sv1.Board, minoes []t4sv1.Mino) ([]t4sv1.Action, bool, time.Duration, error) {
+	logger := log.FromContext(ctx)
+	logger.Info("reconcile Action")
 
-	case "rotate":
-		coords := []t4sv1.Coord{}
-		for _, coord := range mino.RelativeCoords {
-			newCoord := t4sv1.Coord{X: coord.Y, Y: -coord.X}
-			coords = append(coords, newCoord)
-		}
-		mino.RelativeCoords = coords
-		setAbsoluteCoords(&mino)
-		if isCollision(*board, mino.AbsoluteCoords) {
-			return
+	logger.Info("list Actions")
+	actions := t4sv1.ActionList{}
+	err := r.List(ctx, &actions, client.InNamespace(board.Namespace), client.MatchingLabels{
+		constants.ActionBoardLabel: board.Name,
+	})
+	if err != nil {
+		logger.Error(err, "failed to list Actions")
+		return nil, false, 0, err
+	}
+
+	appliedStatus := make(map[string]t4sv1.ActionStatus)
+	for _, applied := range board.Status.AppliedActions {
+		appliedStatus[applied.Name] = applied.Status
+	}
+	var pendingActions, processedActions, unfinishedActions []t4sv1.Action
+	for _, action := range actions.Items {
+		status, applied := appliedStatus[action.Name]
+		switch {
+		case action.Status.Result != "":
+			processedActions = append(processedActions, action)
+		case applied:
+			logger.Info("Action has already been applied", "name", action.GetName())
+			action.Status = status
+			unfinishedActions = append(unfinishedActions, action)
+		default:
+			pendingActions = append(pendingActions, action)
 		}
-		board.Status.CurrentMino[0] = mino
-
-	case "drop":
-		var minoFrom t4sv1.CurrentMino
-		for {
-			minoFrom = mino.DeepCopy()
-			mino.Center.Y++
-			setAbsoluteCoords(&mino)
-			if isCollision(*board, mino.AbsoluteCoords) {
-				break
-			}
+	}
+	sortActions(pendingActions)
+
+	maxActions := r.MaxActionsPerReconcile
+	if maxActions <= 0 {
+		maxActions = DefaultMaxActionsPerReconcile
+	}
+	pending := len(pendingActions) > maxActions
+	if pending {
+		logger.Info("too many Actions. the rest will be applied in the next reconciliation", "actions", len(pendingActions))
+		pendingActions = pendingActions[:maxActions]
+	}
+
+	for j := range pendingActions {
+		action := &pendingActions[j]
+		logger.Info("Action found", "name", action.GetName(), "op", action.Spec.Op)
+		reconcileCurrentMino(ctx, board, minoes)
+		action.Status.Result = applyAction(ctx, board, minoes, *action)
+		now := metav1.NowMicro()
+		action.Status.ProcessedAt = &now
+		if i := findCurrentMino(board, action.Spec.Player); i >= 0 {
+			action.Status.Coords = board.Status.CurrentMino[i].AbsoluteCoords
+		} else if action.Spec.Player == "" && len(board.Status.CurrentMino) != 0 {
+			action.Status.Coords = board.Status.CurrentMino[0].AbsoluteCoords
 		}
-		for _, coord := range minoFrom.AbsoluteCoords {
-			board.Status.Data[coord.Y][coord.X] = board.Status.CurrentMino[0].MinoID
+		if isUserAction(*action) {
+			board.Status.LastUserActionTime = &now
 		}
-		board.Status.CurrentMino[0] = minoFrom
-		// "drop" does not fix the current mino and delegate it to "down" to get time to render when the row is completed
 	}
 
-	logger.Info("move CurrentMino successfully")
+	expireAfter, err := r.deleteExpiredActions(ctx, processedActions)
+	if err != nil {
+		return nil, false, 0, err
+	}
+	if r.ActionTTL > 0 && len(pendingActions) != 0 {
+		expireAfter = shorterRequeue(expireAfter, r.ActionTTL)
+	}
+
+	appliedActions := append(unfinishedActions, pendingActions...)
+	board.Status.AppliedActions = nil
+	for _, action := range appliedActions {
+		board.Status.AppliedActions = append(board.Status.AppliedActions, t4sv1.AppliedAction{Name: action.Name, Status: action.Status})
+	}
+
+	logger.Info("reconcile Action successfully")
+	return appliedActions, pending, expireAfter, nil
 }
 
-func checkRemoveRows(ctx context.Context, board *t4sv1.Board) {
+// finishActions deletes the applied Actions, or records their results in their status if ActionTTL is set.
+func (r *BoardReconciler) finishActions(ctx context.Context, actions []t4sv1.Action) error {
 	logger := log.FromContext(ctx)
-	logger.Info("check and remove rows")
-
-	// Calc Ys of the rows to be removed
-	removeYs := make(map[int]bool)
-	for _, coord := range board.Status.CurrentMino[0].AbsoluteCoords {
-		y := coord.Y
-		if _, exists := removeYs[y]; !exists {
-			completed := true
-			for x := 0; x < board.Spec.Width; x++ {
-				if board.Status.Data[y][x] == 0 {
-					completed = false
-					break
-				}
-			}
-			if completed {
-				removeYs[y] = true
+
+	for _, action := range actions {
+		if r.ActionTTL <= 0 {
+			logger.Info("delete Action", "name", action.GetName(), "result", action.Status.Result)
+			if err := r.Delete(ctx, &action); client.IgnoreNotFound(err) != nil {
+				logger.Error(err, "failed to delete action")
+				return err
 			}
+			continue
+		}
+		logger.Info("update the status of Action", "name", action.GetName(), "result", action.Status.Result)
+		if err := r.Status().Update(ctx, &action); client.IgnoreNotFound(err) != nil {
+			logger.Error(err, "failed to update the status of action")
+			return err
 		}
 	}
+	return nil
+}
 
-	if len(removeYs) == 0 {
-		logger.Info("no rows to remove")
-		return
+// applyAction applies the Action to the current mino of its player, or to all the current minoes if the Action has no player.
+// The result is "Applied" if the Action is applied to any of them.
+func applyAction(ctx context.Context, board *t4sv1.Board, minoes []t4sv1.Mino, action t4sv1.Action) t4sv1.ActionResult {
+	var targets []string
+	if action.Spec.Player != "" {
+		targets = []string{action.Spec.Player}
+	} else {
+		for _, mino := range board.Status.CurrentMino {
+			targets = append(targets, mino.Player)
+		}
 	}
 
-	// Drop rows except the ones to be removed
-	newY := board.Spec.Height - 1
-	for y := board.Spec.Height - 2; y >= 0; y-- {
-		if removeYs[y] {
+	result := t4sv1.ActionIgnored
+	for _, player := range targets {
+		i := findCurrentMino(board, player)
+		if board.Status.State != t4sv1.Playing || i < 0 {
 			continue
 		}
-		copy(board.Status.Data[newY], board.Status.Data[y])
-		newY--
+		var r t4sv1.ActionResult
+		if action.Spec.Op == "hold" {
+			r = holdMino(ctx, board, minoes, i)
+		} else {
+			r = moveCurrentMino(ctx, board, minoes, i, action.Spec.Op, isUserAction(action))
+		}
+		if result == t4sv1.ActionIgnored || r == t4sv1.ActionApplied {
+			result = r
+		}
 	}
-
-	RemovedRowsVec.WithLabelValues(board.Namespace).Observe(float64(len(removeYs)))
-
-	logger.Info("check and remove rows successfully", "removed rows", len(removeYs))
+	return result
 }
 
-func (r *BoardReconciler) reconcileAction(ctx context.Context, board *t4sv1.Board) error {
+// deleteExpiredActions deletes the processed Actions whose ActionTTL has passed.
+// It returns the time left until the next one expires.
+func (r *BoardReconciler) deleteExpiredActions(ctx context.Context, actions []t4sv1.Action) (time.Duration, error) {
 	logger := log.FromContext(ctx)
-	logger.Info("reconcile Action")
 
-	logger.Info("list Actions")
-	actions := t4sv1.ActionList{}
-	err := r.List(ctx, &actions, &client.ListOptions{
-		Namespace: board.Namespace,
-	})
-	if err != nil {
-		logger.Error(err, "failed to list Actions")
-		return err
+	var expireAfter time.Duration
+	for _, action := range actions {
+		if action.Status.ProcessedAt != nil {
+			if remaining := r.ActionTTL - time.Since(action.Status.ProcessedAt.Time); remaining > 0 {
+				expireAfter = shorterRequeue(expireAfter, remaining)
+				continue
+			}
+		}
+		logger.Info("delete Action", "name", action.GetName())
+		if err := r.Delete(ctx, &action); client.IgnoreNotFound(err) != nil {
+			logger.Error(err, "failed to delete action")
+			return 0, err
+		}
 	}
+	return expireAfter, nil
+}
 
-	if len(actions.Items) != 0 {
-		// Process the first Action only
-		action := actions.Items[0]
-		logger.Info("Action found", "name", action.GetName())
-		if board.Status.State != t4sv1.GameOver {
-			moveCurrentMino(ctx, board, action.Spec.Op)
+// sortActions sorts the Actions in the order they are requested.
+// They are sorted by the sequence number, and then by creationTimestamp and the name, which break ties
+// of the Actions created without a sequence number, for instance by kubectl.
+func sortActions(actions []t4sv1.Action) {
+	sort.SliceStable(actions, func(i, j int) bool {
+		if si, sj := actions[i].Spec.Sequence, actions[j].Spec.Sequence; si != sj {
+			return si < sj
 		}
-		for _, action := range actions.Items {
-			logger.Info("delete Action", "name", action.GetName())
-			err = r.Delete(ctx, &action)
-			if err != nil {
-				logger.Error(err, "failed to delete action")
-				return err
-			}
+		ti, tj := actions[i].CreationTimestamp, actions[j].CreationTimestamp
+		if !ti.Equal(&tj) {
+			return ti.Before(&tj)
 		}
-	}
+		return actions[i].Name < actions[j].Name
+	})
+}
 
-	logger.Info("reconcile Action successfully")
-	return nil
+// isUserAction returns true if the Action is requested by the user, not by Cron.
+func isUserAction(action t4sv1.Action) bool {
+	return action.GetLabels()[constants.ActionSourceLabel] != constants.ActionSourceCron
 }
 
 func (r *BoardReconciler) reconcileCron(ctx context.Context, board *t4sv1.Board) error {
 	logger := log.FromContext(ctx)
 	logger.Info("reconcile Cron")
 
-	if board.Status.State == t4sv1.Playing {
+	if board.Status.State == t4sv1.Playing || (board.Status.State == t4sv1.Paused && !isIdlePaused(board)) {
 		cron := &t4sv1.Cron{}
 		cron.SetNamespace(board.Namespace)
-		cron.SetName("cron")
+		cron.SetName(board.Name)
 		op, err := ctrl.CreateOrUpdate(ctx, r.Client, cron, func() error {
-			cron.Spec.Period = board.Spec.Wait
+			cron.Spec.Period = board.Status.Wait
+			cron.Spec.Suspend = board.Status.State == t4sv1.Paused
 			return ctrl.SetControllerReference(board, cron, r.Scheme)
 		})
 		if err != nil {
@@ -347,11 +477,11 @@ func (r *BoardReconciler) reconcileCron(ctx context.Context, board *t4sv1.Board)
 			logger.Info("reconcile Cron successfully", "op", op)
 		}
 	} else {
-		// when board.Status.State == GameOver
+		// when board.Status.State == GameOver or the game is paused by the idle timeout
 		var cron t4sv1.Cron
 		err := r.Get(ctx, client.ObjectKey{
 			Namespace: board.Namespace,
-			Name:      "cron",
+			Name:      board.Name,
 		}, &cron)
 		if errors.IsNotFound(err) {
 			logger.Info("Cron not found")
@@ -118,7 +118,7 @@ var _ = Describe("Board controller", func() {
 		By("checking Cron will NOT be created")
 		cron := &t4sv1.Cron{}
 		Eventually(func() error {
-			return k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: "cron"}, cron)
+			return k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: constants.BoardName}, cron)
 		}).ShouldNot(Succeed())
 	})
 
@@ -181,6 +181,9 @@ var _ = Describe("Board controller", func() {
 			if board.Status.CurrentMino[0].MinoID != 1 {
 				return errors.New("board.Status.CurrentMino[0].MinoID != 1")
 			}
+			if !reflect.DeepEqual(board.Status.Next, []int{1, 1, 1}) {
+				return fmt.Errorf("board.Status.Next doesn't have the expected value %v, got %v", []int{1, 1, 1}, board.Status.Next)
+			}
 			if board.Status.State != t4sv1.Playing {
 				return errors.New("board.Status.State != t4sv1.Playing")
 			}
@@ -190,13 +193,81 @@ var _ = Describe("Board controller", func() {
 		By("checking Cron will be created")
 		cron := &t4sv1.Cron{}
 		Eventually(func() error {
-			return k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: "cron"}, cron)
+			return k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: constants.BoardName}, cron)
 		}).Should(Succeed())
 		Expect(cron.Spec).To(MatchFields(IgnoreExtras, Fields{
 			"Period": Equal(1000),
 		}))
 	})
 
+	It("should set the Cron period following the speed curve", func() {
+		By("creating a namespace, a Mino, and a Board")
+		nsName := "test-ns-board-speed-curve"
+		ns := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: nsName,
+			},
+		}
+		err := k8sClient.Create(ctx, ns)
+		Expect(err).NotTo(HaveOccurred())
+
+		mino := &t4sv1.Mino{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: nsName,
+				Name:      "mino-i",
+			},
+			Spec: t4sv1.MinoSpec{
+				MinoID: 1,
+				Coords: []t4sv1.Coord{
+					{X: -1, Y: 0},
+					{X: 0, Y: 0},
+					{X: 1, Y: 0},
+					{X: 2, Y: 0},
+				},
+				Color: "#a0d8ef",
+			},
+		}
+		err = k8sClient.Create(ctx, mino)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		board := &t4sv1.Board{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: nsName,
+				Name:      constants.BoardName,
+			},
+			Spec: t4sv1.BoardSpec{
+				Width:  10,
+				Height: 20,
+				Wait:   1000,
+				SpeedCurve: []t4sv1.SpeedLevel{
+					{Level: 1, Wait: 800},
+					{Level: 2, Wait: 600},
+				},
+				State: t4sv1.Playing,
+			},
+		}
+		err = k8sClient.Create(ctx, board)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		By("checking the wait at level 1 will be recorded in BoardStatus")
+		Eventually(func() error {
+			if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: constants.BoardName}, board); err != nil {
+				return err
+			}
+			if board.Status.Wait != 800 {
+				return fmt.Errorf("board.Status.Wait is not 800, got %v", board.Status.Wait)
+			}
+			return nil
+		}).Should(Succeed())
+
+		By("checking Cron will be created with the wait at level 1")
+		cron := &t4sv1.Cron{}
+		Eventually(func() error {
+			return k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: constants.BoardName}, cron)
+		}).Should(Succeed())
+		Expect(cron.Spec.Period).To(Equal(800))
+	})
+
 	It("should move the current mino down successfully", func() {
 		By("creating a namespace and a Mino")
 		nsName := "test-ns-board-down"
@@ -258,6 +329,9 @@ var _ = Describe("Board controller", func() {
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: nsName,
 				Name:      "action-1",
+				Labels: map[string]string{
+					constants.ActionBoardLabel: constants.BoardName,
+				},
 			},
 			Spec: t4sv1.ActionSpec{
 				Op: "down",
@@ -337,6 +411,114 @@ var _ = Describe("Board controller", func() {
 			if !reflect.DeepEqual(board.Status.CurrentMino[0].AbsoluteCoords, expected) {
 				return fmt.Errorf("board.Status.CurrentMino[0].AbsoluteCoords doesn't have the expected value %v, got %v", expected, board.Status.CurrentMino[0].AbsoluteCoords)
 			}
+			expectedGhost := []t4sv1.Coord{
+				{X: 2, Y: 19},
+				{X: 3, Y: 19},
+				{X: 4, Y: 19},
+				{X: 5, Y: 19},
+			}
+			if !reflect.DeepEqual(board.Status.CurrentMino[0].GhostCoords, expectedGhost) {
+				return fmt.Errorf("board.Status.CurrentMino[0].GhostCoords doesn't have the expected value %v, got %v", expectedGhost, board.Status.CurrentMino[0].GhostCoords)
+			}
+			return nil
+		}).Should(Succeed())
+	})
+
+	It("should hold the current mino and take the next one", func() {
+		By("creating a namespace and Minoes")
+		nsName := "test-ns-board-hold"
+		ns := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: nsName,
+			},
+		}
+		err := k8sClient.Create(ctx, ns)
+		Expect(err).NotTo(HaveOccurred())
+
+		mino := &t4sv1.Mino{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: nsName,
+				Name:      "mino-i",
+			},
+			Spec: t4sv1.MinoSpec{
+				MinoID: 1,
+				Coords: []t4sv1.Coord{
+					{X: -1, Y: 0},
+					{X: 0, Y: 0},
+					{X: 1, Y: 0},
+					{X: 2, Y: 0},
+				},
+				Color: "#a0d8ef",
+			},
+		}
+		err = k8sClient.Create(ctx, mino)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		By("creating a Board")
+		board := &t4sv1.Board{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: nsName,
+				Name:      constants.BoardName,
+			},
+			Spec: t4sv1.BoardSpec{
+				Width:  10,
+				Height: 20,
+				Wait:   1000,
+				State:  t4sv1.Playing,
+			},
+		}
+		err = k8sClient.Create(ctx, board)
+		Expect(err).ShouldNot(HaveOccurred())
+		// Wait for Board to be fully reconciled.
+		Eventually(func() error {
+			if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: constants.BoardName}, board); err != nil {
+				return err
+			}
+			if len(board.Status.CurrentMino) != 1 {
+				return fmt.Errorf("len(board.Status.CurrentMino) is not 1")
+			}
+			return nil
+		}).Should(Succeed())
+
+		By("creating an Action")
+		action := &t4sv1.Action{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: nsName,
+				Name:      "action-1",
+				Labels: map[string]string{
+					constants.ActionBoardLabel: constants.BoardName,
+				},
+			},
+			Spec: t4sv1.ActionSpec{
+				Op: "hold",
+			},
+		}
+		err = k8sClient.Create(ctx, action)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		By("triggering reconciliation")
+		_, err = reconciler.Reconcile(ctx, ctrl.Request{
+			NamespacedName: types.NamespacedName{
+				Namespace: nsName,
+				Name:      constants.BoardName,
+			},
+		})
+		Expect(err).ShouldNot(HaveOccurred())
+
+		By("checking the current mino will be held")
+		Eventually(func() error {
+			if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: constants.BoardName}, board); err != nil {
+				return err
+			}
+			if board.Status.HoldMino != 1 {
+				return fmt.Errorf("board.Status.HoldMino is not 1, got %v", board.Status.HoldMino)
+			}
+			if len(board.Status.CurrentMino) != 1 {
+				return errors.New("len(board.Status.CurrentMino) is not 1")
+			}
+			if !board.Status.CurrentMino[0].HoldUsed {
+				return errors.New("board.Status.CurrentMino[0].HoldUsed should be true")
+			}
 			return nil
 		}).Should(Succeed())
 	})
@@ -402,6 +584,9 @@ var _ = Describe("Board controller", func() {
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: nsName,
 				Name:      "action-1",
+				Labels: map[string]string{
+					constants.ActionBoardLabel: constants.BoardName,
+				},
 			},
 			Spec: t4sv1.ActionSpec{
 				Op: "down",
@@ -480,6 +665,14 @@ var _ = Describe("Board controller", func() {
 			}
 			return nil
 		}).Should(Succeed())
+
+		By("checking the score, the lines and the pieces will be updated")
+		Expect(board.Status).To(MatchFields(IgnoreExtras, Fields{
+			"Score":  Equal(100),
+			"Lines":  Equal(1),
+			"Level":  Equal(1),
+			"Pieces": Equal(1),
+		}))
 	})
 
 	It("should change State to GameOver and delete Cron", func() {
@@ -541,7 +734,7 @@ var _ = Describe("Board controller", func() {
 		By("checking Cron is created")
 		cron := &t4sv1.Cron{}
 		Eventually(func() error {
-			return k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: "cron"}, cron)
+			return k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: constants.BoardName}, cron)
 		}).Should(Succeed())
 
 		By("updating the status of the board")
@@ -590,7 +783,7 @@ var _ = Describe("Board controller", func() {
 
 		By("checking Cron will be deleted")
 		Eventually(func() error {
-			return k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: "cron"}, cron)
+			return k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: constants.BoardName}, cron)
 		}).ShouldNot(Succeed())
 	})
 })
@@ -0,0 +1,1026 @@
+package controllers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	t4sv1 "github.com/tkna/t4s/api/v1"
+	"github.com/tkna/t4s/pkg/constants"
+	"github.com/tkna/t4s/pkg/engine"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// The tests in this file call the game logic of the Board controller directly, so they need neither kube-apiserver nor etcd.
+// They are plain Go tests rather than Ginkgo specs, because every spec of the Ginkgo suite waits for BeforeSuite to start envtest.
+
+// Shapes of the minoes used by the tests
+var (
+	testOCoords = []t4sv1.Coord{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}}
+	testTCoords = []t4sv1.Coord{{X: -1, Y: 0}, {X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 0}}
+)
+
+// newTestBoard returns a Board being played at level 1 with the cells and the current minoes.
+// The size of the board is taken from the cells.
+func newTestBoard(data [][]int, minoes ...t4sv1.CurrentMino) *t4sv1.Board {
+	for i := range minoes {
+		setAbsoluteCoords(&minoes[i])
+	}
+	return &t4sv1.Board{
+		Spec: t4sv1.BoardSpec{Width: len(data[0]), Height: len(data)},
+		Status: t4sv1.BoardStatus{
+			Data:        data,
+			CurrentMino: minoes,
+			State:       t4sv1.Playing,
+			Level:       1,
+		},
+	}
+}
+
+// emptyData returns the cells of an empty board.
+func emptyData(width, height int) [][]int {
+	data := make([][]int, height)
+	for y := range data {
+		data[y] = make([]int, width)
+	}
+	return data
+}
+
+// restingOMino returns O mino resting on the bottom of the board of emptyData(6, 5).
+func restingOMino() t4sv1.CurrentMino {
+	return t4sv1.CurrentMino{MinoID: 2, Center: t4sv1.Coord{X: 2, Y: 4}, RelativeCoords: testOCoords}
+}
+
+// setAbsoluteCoords updates the absolute coordinates of the current mino after its center or shape is changed.
+func setAbsoluteCoords(mino *t4sv1.CurrentMino) {
+	setPiece(mino, gamePiece(*mino))
+}
+
+// newTestReconciler returns a BoardReconciler with a fake client which has the objects.
+func newTestReconciler(t *testing.T, objs ...client.Object) *BoardReconciler {
+	scheme := runtime.NewScheme()
+	if err := t4sv1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	return &BoardReconciler{Client: c, Scheme: scheme}
+}
+
+func TestBoardLockDelay(t *testing.T) {
+	ctx := context.Background()
+
+	newBoard := func(lockDelay, maxLockResets int) *t4sv1.Board {
+		board := newTestBoard(emptyData(6, 5), restingOMino())
+		board.Spec.LockDelay = lockDelay
+		board.Spec.MaxLockResets = maxLockResets
+		return board
+	}
+
+	t.Run("should fix the mino immediately without lock delay", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newBoard(0, 15)
+		moveCurrentMino(ctx, board, nil, 0, "down", false)
+		g.Expect(board.Status.CurrentMino).To(BeEmpty())
+		g.Expect(board.Status.Pieces).To(Equal(1))
+		g.Expect(board.Status.Data[4][2]).To(Equal(2))
+	})
+
+	t.Run("should not fix the mino by moving it sideways without lock delay", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newBoard(0, 15)
+		moveCurrentMino(ctx, board, nil, 0, "right", true)
+		g.Expect(board.Status.CurrentMino[0].LockStartTime).To(BeNil())
+		g.Expect(reconcileLockDelay(ctx, board)).To(BeZero())
+		g.Expect(board.Status.CurrentMino).To(HaveLen(1))
+		g.Expect(board.Status.Pieces).To(BeZero())
+	})
+
+	t.Run("should fix the mino after the lock delay", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newBoard(500, 15)
+		t.Log("touching the ground")
+		moveCurrentMino(ctx, board, nil, 0, "down", false)
+		g.Expect(board.Status.CurrentMino).To(HaveLen(1))
+		g.Expect(board.Status.CurrentMino[0].LockStartTime).NotTo(BeNil())
+		g.Expect(reconcileLockDelay(ctx, board)).To(BeNumerically(">", 0))
+		g.Expect(board.Status.CurrentMino).To(HaveLen(1))
+
+		t.Log("passing the lock delay")
+		past := metav1.NewMicroTime(time.Now().Add(-time.Second))
+		board.Status.CurrentMino[0].LockStartTime = &past
+		g.Expect(reconcileLockDelay(ctx, board)).To(BeZero())
+		g.Expect(board.Status.CurrentMino).To(BeEmpty())
+		g.Expect(board.Status.Pieces).To(Equal(1))
+	})
+
+	t.Run("should reset the lock delay by moving the mino up to the limit", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newBoard(500, 2)
+		moveCurrentMino(ctx, board, nil, 0, "down", false)
+		for i := 1; i <= 3; i++ {
+			past := metav1.NewMicroTime(time.Now().Add(-time.Second))
+			board.Status.CurrentMino[0].LockStartTime = &past
+			moveCurrentMino(ctx, board, nil, 0, "left", true)
+			if i <= 2 {
+				g.Expect(board.Status.CurrentMino[0].LockResets).To(Equal(i))
+				g.Expect(newGame(board, nil).LockRemaining(0, time.Now())).To(BeNumerically(">", 0))
+			} else {
+				g.Expect(board.Status.CurrentMino[0].LockResets).To(Equal(2))
+				g.Expect(newGame(board, nil).LockRemaining(0, time.Now())).To(BeZero())
+			}
+		}
+		moveCurrentMino(ctx, board, nil, 0, "down", false)
+		g.Expect(board.Status.CurrentMino).To(BeEmpty())
+	})
+
+	t.Run("should clear the lock delay when the mino leaves the ground after the limit", func(t *testing.T) {
+		g := NewWithT(t)
+		data := emptyData(6, 5)
+		data[3][2], data[3][3], data[4][2], data[4][3] = 1, 1, 1, 1
+		board := newTestBoard(data, t4sv1.CurrentMino{MinoID: 2, Center: t4sv1.Coord{X: 2, Y: 2}, RelativeCoords: testOCoords})
+		board.Spec.LockDelay = 500
+		now := metav1.NowMicro()
+		board.Status.CurrentMino[0].LockStartTime = &now
+		g.Expect(board.Status.CurrentMino[0].LockStartTime).NotTo(BeNil())
+
+		t.Log("moving on the ledge")
+		moveCurrentMino(ctx, board, nil, 0, "left", true)
+		g.Expect(board.Status.CurrentMino[0].LockStartTime).NotTo(BeNil())
+
+		t.Log("moving off the ledge")
+		moveCurrentMino(ctx, board, nil, 0, "left", true)
+		g.Expect(board.Status.CurrentMino[0].LockStartTime).To(BeNil())
+		g.Expect(board.Status.CurrentMino[0].LockResets).To(BeZero())
+		g.Expect(reconcileLockDelay(ctx, board)).To(BeZero())
+		g.Expect(board.Status.CurrentMino).To(HaveLen(1))
+	})
+}
+
+func TestBoardHardDrop(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should fix the mino, remove the rows and spawn the next mino at once", func(t *testing.T) {
+		g := NewWithT(t)
+		minoes := []t4sv1.Mino{{Spec: t4sv1.MinoSpec{MinoID: 2, Coords: testOCoords}}}
+		board := newTestBoard([][]int{
+			{0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0},
+			{1, 1, 0, 0, 1, 1},
+		}, t4sv1.CurrentMino{MinoID: 2, Center: t4sv1.Coord{X: 2, Y: 1}, RelativeCoords: testOCoords})
+		board.Spec.LockDelay = 500
+		board.Spec.NextCount = 1
+
+		moveCurrentMino(ctx, board, minoes, 0, "drop", true)
+		g.Expect(board.Status.Data).To(Equal([][]int{
+			{0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0},
+			{0, 0, 2, 2, 0, 0},
+		}))
+		g.Expect(board.Status.ClearedRows).To(Equal([]int{4}))
+		g.Expect(board.Status.Lines).To(Equal(1))
+		g.Expect(board.Status.Pieces).To(Equal(1))
+		g.Expect(board.Status.Score).To(Equal(100 + 3*engine.HardDropScore))
+		g.Expect(board.Status.CurrentMino).To(HaveLen(1))
+		g.Expect(board.Status.CurrentMino[0].Center).To(Equal(t4sv1.Coord{X: 2, Y: 2}))
+	})
+}
+
+func TestBoardSpeedCurve(t *testing.T) {
+	g := NewWithT(t)
+	board := newTestBoard(emptyData(6, 5))
+	board.Spec.Wait = 1000
+	board.Spec.SpeedCurve = []t4sv1.SpeedLevel{{Level: 5, Wait: 100}, {Level: 2, Wait: 600}}
+	g.Expect(currentWait(board)).To(Equal(1000))
+
+	board.Status.Level = 3
+	g.Expect(currentWait(board)).To(Equal(600))
+
+	t.Log("clamping the wait to the floor")
+	board.Status.Level = 5
+	g.Expect(currentWait(board)).To(Equal(minWait))
+}
+
+func TestBoardActions(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("should report the results of the ops", func(t *testing.T) {
+		g := NewWithT(t)
+		minoes := []t4sv1.Mino{{Spec: t4sv1.MinoSpec{MinoID: 2, Coords: testOCoords}}}
+		board := newTestBoard(emptyData(6, 5))
+		board.Spec.NextCount = 1
+		reconcileCurrentMino(ctx, board, minoes)
+		g.Expect(board.Status.CurrentMino).To(HaveLen(1))
+
+		g.Expect(moveCurrentMino(ctx, board, minoes, 0, "left", true)).To(Equal(t4sv1.ActionApplied))
+		g.Expect(moveCurrentMino(ctx, board, minoes, 0, "left", true)).To(Equal(t4sv1.ActionApplied))
+		g.Expect(moveCurrentMino(ctx, board, minoes, 0, "left", true)).To(Equal(t4sv1.ActionBlocked))
+		g.Expect(moveCurrentMino(ctx, board, minoes, 0, "jump", true)).To(Equal(t4sv1.ActionInvalid))
+		g.Expect(holdMino(ctx, board, minoes, 0)).To(Equal(t4sv1.ActionApplied))
+		g.Expect(holdMino(ctx, board, minoes, 0)).To(Equal(t4sv1.ActionBlocked))
+	})
+
+	newAction := func(name, op string) *t4sv1.Action {
+		return &t4sv1.Action{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: "default",
+				Name:      name,
+				Labels:    map[string]string{constants.ActionBoardLabel: "board"},
+			},
+			Spec: t4sv1.ActionSpec{Op: op},
+		}
+	}
+
+	t.Run("should not apply the Actions again which have been applied", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newTestBoard(emptyData(6, 5), restingOMino())
+		board.ObjectMeta = metav1.ObjectMeta{Namespace: "default", Name: "board"}
+		r := newTestReconciler(t, newAction("action-a", "left"))
+
+		applied, _, _, err := r.reconcileAction(ctx, board, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(applied).To(HaveLen(1))
+		g.Expect(board.Status.CurrentMino[0].Center.X).To(Equal(1))
+		g.Expect(board.Status.AppliedActions).To(HaveLen(1))
+
+		t.Log("reconciling again before the Action is deleted")
+		applied, _, _, err = r.reconcileAction(ctx, board, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(applied).To(HaveLen(1))
+		g.Expect(applied[0].Status.Result).To(Equal(t4sv1.ActionApplied))
+		g.Expect(board.Status.CurrentMino[0].Center.X).To(Equal(1))
+
+		t.Log("reconciling after the Action is deleted")
+		g.Expect(r.finishActions(ctx, applied)).To(Succeed())
+		applied, _, _, err = r.reconcileAction(ctx, board, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(applied).To(BeEmpty())
+		g.Expect(board.Status.AppliedActions).To(BeEmpty())
+	})
+
+	t.Run("should requeue the Board when the next processed Action expires", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newTestBoard(emptyData(6, 5))
+		board.ObjectMeta = metav1.ObjectMeta{Namespace: "default", Name: "board"}
+		expired := newAction("action-a", "left")
+		expired.Status.Result = t4sv1.ActionApplied
+		expiredAt := metav1.NewMicroTime(time.Now().Add(-2 * time.Minute))
+		expired.Status.ProcessedAt = &expiredAt
+		kept := newAction("action-b", "left")
+		kept.Status.Result = t4sv1.ActionApplied
+		keptAt := metav1.NewMicroTime(time.Now().Add(-30 * time.Second))
+		kept.Status.ProcessedAt = &keptAt
+		r := newTestReconciler(t, expired, kept)
+		r.ActionTTL = time.Minute
+
+		_, _, expireAfter, err := r.reconcileAction(ctx, board, nil)
+		g.Expect(err).NotTo(HaveOccurred())
+		g.Expect(expireAfter).To(BeNumerically(">", 0))
+		g.Expect(expireAfter).To(BeNumerically("<=", 30*time.Second))
+		actions := &t4sv1.ActionList{}
+		g.Expect(r.List(ctx, actions)).To(Succeed())
+		g.Expect(actions.Items).To(HaveLen(1))
+		g.Expect(actions.Items[0].Name).To(Equal("action-b"))
+	})
+
+	t.Run("should sort the Actions in the order they are requested", func(t *testing.T) {
+		g := NewWithT(t)
+		base := time.Now().Truncate(time.Second)
+		newAction := func(name string, created time.Time, sequence int64) t4sv1.Action {
+			return t4sv1.Action{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              name,
+					CreationTimestamp: metav1.NewTime(created),
+				},
+				Spec: t4sv1.ActionSpec{Sequence: sequence},
+			}
+		}
+		actions := []t4sv1.Action{
+			newAction("action-c", base.Add(time.Second), 0),
+			newAction("action-b", base, 0),
+			newAction("action-a", base, 0),
+			newAction("action-d", base.Add(-time.Second), 0),
+			newAction("action-f", base, 200),
+			newAction("action-e", base, 300),
+			newAction("action-g", base.Add(-time.Second), 300),
+		}
+		sortActions(actions)
+		var names []string
+		for _, action := range actions {
+			names = append(names, action.Name)
+		}
+		g.Expect(names).To(Equal([]string{"action-d", "action-a", "action-b", "action-c", "action-f", "action-g", "action-e"}))
+	})
+
+	t.Run("should number the Actions in the order they are created", func(t *testing.T) {
+		g := NewWithT(t)
+		last := t4sv1.NewActionSequence()
+		for i := 0; i < 100; i++ {
+			seq := t4sv1.NewActionSequence()
+			g.Expect(seq).To(BeNumerically(">", last))
+			last = seq
+		}
+	})
+}
+
+func TestBoardPause(t *testing.T) {
+	ctx := context.Background()
+
+	newBoard := func() *t4sv1.Board {
+		mino := restingOMino()
+		now := metav1.NowMicro()
+		mino.LockStartTime = &now
+		board := newTestBoard(emptyData(6, 5), mino)
+		board.Spec.LockDelay = 500
+		board.Spec.State = t4sv1.Playing
+		return board
+	}
+
+	t.Run("should pause and resume the game following the spec", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newBoard()
+		board.Spec.State = t4sv1.Paused
+		reconcileState(ctx, board)
+		g.Expect(board.Status.State).To(Equal(t4sv1.Paused))
+		g.Expect(board.Status.CurrentMino[0].LockStartTime).To(BeNil())
+		g.Expect(reconcileLockDelay(ctx, board)).To(BeZero())
+		g.Expect(board.Status.CurrentMino).To(HaveLen(1))
+
+		board.Spec.State = t4sv1.Playing
+		reconcileState(ctx, board)
+		g.Expect(board.Status.State).To(Equal(t4sv1.Playing))
+	})
+
+	t.Run("should not resume the game which is over", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newBoard()
+		board.Status.State = t4sv1.GameOver
+		reconcileState(ctx, board)
+		g.Expect(board.Status.State).To(Equal(t4sv1.GameOver))
+	})
+
+	t.Run("should count the play time only while playing", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newBoard()
+		start := time.Now()
+		reconcileElapsedTime(board, start)
+		g.Expect(board.Status.ElapsedTime).To(BeZero())
+		g.Expect(board.Status.ElapsedTimeUpdatedAt).NotTo(BeNil())
+
+		t.Log("playing for 1.5 sec")
+		reconcileElapsedTime(board, start.Add(1500*time.Millisecond+300*time.Microsecond))
+		g.Expect(board.Status.ElapsedTime).To(Equal(int64(1500)))
+
+		t.Log("pausing for 10 sec")
+		board.Status.State = t4sv1.Paused
+		reconcileElapsedTime(board, start.Add(2*time.Second))
+		g.Expect(board.Status.ElapsedTime).To(Equal(int64(2000)))
+		g.Expect(board.Status.ElapsedTimeUpdatedAt).To(BeNil())
+		reconcileElapsedTime(board, start.Add(12*time.Second))
+		g.Expect(board.Status.ElapsedTime).To(Equal(int64(2000)))
+
+		t.Log("playing for 1 sec after resumed")
+		board.Status.State = t4sv1.Playing
+		reconcileElapsedTime(board, start.Add(12*time.Second))
+		reconcileElapsedTime(board, start.Add(13*time.Second))
+		g.Expect(board.Status.ElapsedTime).To(Equal(int64(3000)))
+	})
+}
+
+func TestBoardIdleTimeout(t *testing.T) {
+	ctx := context.Background()
+
+	newBoard := func(idleState t4sv1.IdleState) *t4sv1.Board {
+		board := newTestBoard(emptyData(6, 5))
+		board.Spec.IdleTimeoutSeconds = 60
+		board.Spec.IdleState = idleState
+		board.Spec.State = t4sv1.Playing
+		return board
+	}
+
+	t.Run("should pause the game when no Action is requested by the user", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newBoard(t4sv1.IdlePaused)
+		start := time.Now()
+		g.Expect(reconcileIdleTimeout(ctx, board, start)).To(Equal(time.Minute))
+		g.Expect(board.Status.LastUserActionTime).NotTo(BeNil())
+
+		t.Log("requesting an Action after 30 sec")
+		userAction := metav1.NewMicroTime(start.Add(30 * time.Second))
+		board.Status.LastUserActionTime = &userAction
+		g.Expect(reconcileIdleTimeout(ctx, board, start.Add(time.Minute))).To(Equal(30 * time.Second))
+		g.Expect(board.Status.State).To(Equal(t4sv1.Playing))
+
+		t.Log("passing the idle timeout")
+		g.Expect(reconcileIdleTimeout(ctx, board, start.Add(90*time.Second))).To(BeZero())
+		g.Expect(board.Status.State).To(Equal(t4sv1.Paused))
+		g.Expect(board.Status.StateReason).To(Equal(t4sv1.ReasonIdleTimeout))
+		g.Expect(isIdlePaused(board)).To(BeTrue())
+
+		t.Log("resuming the game")
+		// the Board controller records the pause in the spec
+		board.Spec.State = t4sv1.Paused
+		reconcileState(ctx, board)
+		g.Expect(board.Status.State).To(Equal(t4sv1.Paused))
+		board.Spec.State = t4sv1.Playing
+		reconcileState(ctx, board)
+		g.Expect(board.Status.State).To(Equal(t4sv1.Playing))
+		g.Expect(board.Status.StateReason).To(BeEmpty())
+		g.Expect(reconcileIdleTimeout(ctx, board, start.Add(100*time.Second))).To(Equal(time.Minute))
+	})
+
+	t.Run("should record the idle pause in the spec and the status of the Board", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newBoard(t4sv1.IdlePaused)
+		board.ObjectMeta = metav1.ObjectMeta{Namespace: "default", Name: "board"}
+		board.Spec.Wait = 1000
+		board.Status.Seed = 1
+		idleSince := metav1.NewMicroTime(time.Now().Add(-2 * time.Minute))
+		board.Status.LastUserActionTime = &idleSince
+		mino := &t4sv1.Mino{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "mino-o"},
+			Spec:       t4sv1.MinoSpec{MinoID: 2, Coords: testOCoords},
+		}
+		r := newTestReconciler(t, board, mino)
+
+		_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(board)})
+		g.Expect(err).NotTo(HaveOccurred())
+		saved := &t4sv1.Board{}
+		g.Expect(r.Get(ctx, client.ObjectKeyFromObject(board), saved)).To(Succeed())
+		g.Expect(saved.Spec.State).To(Equal(t4sv1.Paused))
+		g.Expect(saved.Status.State).To(Equal(t4sv1.Paused))
+		g.Expect(saved.Status.StateReason).To(Equal(t4sv1.ReasonIdleTimeout))
+	})
+
+	t.Run("should end the game when the idle state is GameOver", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newBoard(t4sv1.IdleGameOver)
+		start := time.Now()
+		reconcileIdleTimeout(ctx, board, start)
+		g.Expect(reconcileIdleTimeout(ctx, board, start.Add(time.Minute))).To(BeZero())
+		g.Expect(board.Status.State).To(Equal(t4sv1.GameOver))
+		g.Expect(board.Status.StateReason).To(Equal(t4sv1.ReasonIdleTimeout))
+	})
+
+	t.Run("should not stop the game when the idle timeout is disabled", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newBoard(t4sv1.IdlePaused)
+		board.Spec.IdleTimeoutSeconds = 0
+		start := time.Now()
+		reconcileIdleTimeout(ctx, board, start)
+		g.Expect(reconcileIdleTimeout(ctx, board, start.Add(time.Hour))).To(BeZero())
+		g.Expect(board.Status.State).To(Equal(t4sv1.Playing))
+	})
+}
+
+func TestBoardModes(t *testing.T) {
+	ctx := context.Background()
+
+	newBoard := func(mode t4sv1.GameMode) *t4sv1.Board {
+		board := newTestBoard(emptyData(6, 5), t4sv1.CurrentMino{MinoID: 1})
+		board.Spec.Mode = mode
+		board.Spec.GoalLines = 40
+		board.Spec.TimeLimitSeconds = 120
+		return board
+	}
+
+	t.Run("should go on in Marathon mode", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newBoard(t4sv1.Marathon)
+		board.Status.Lines = 100
+		board.Status.ElapsedTime = time.Hour.Milliseconds()
+		g.Expect(reconcileGoal(ctx, board)).To(BeZero())
+		g.Expect(board.Status.State).To(Equal(t4sv1.Playing))
+	})
+
+	t.Run("should clear the game when the goal lines are cleared in Sprint mode", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newBoard(t4sv1.Sprint)
+		board.Status.Lines = 39
+		g.Expect(reconcileGoal(ctx, board)).To(BeZero())
+		g.Expect(board.Status.State).To(Equal(t4sv1.Playing))
+
+		board.Status.Lines = 41
+		board.Status.ElapsedTime = 65432
+		reconcileGoal(ctx, board)
+		g.Expect(board.Status.State).To(Equal(t4sv1.Cleared))
+		g.Expect(board.Status.StateReason).To(Equal(t4sv1.ReasonGoalReached))
+		g.Expect(board.Status.CurrentMino).To(BeEmpty())
+		g.Expect(board.Status.ElapsedTime).To(Equal(int64(65432)))
+	})
+
+	t.Run("should count the time until the goal lines are cleared by a drop in Sprint mode", func(t *testing.T) {
+		g := NewWithT(t)
+		data := emptyData(6, 5)
+		data[4] = []int{1, 1, 0, 0, 1, 1}
+		board := newTestBoard(data, t4sv1.CurrentMino{MinoID: 2, Center: t4sv1.Coord{X: 2, Y: 1}, RelativeCoords: testOCoords})
+		board.Spec.Mode = t4sv1.Sprint
+		board.Spec.GoalLines = 1
+		board.Status.ElapsedTime = 10000
+		updatedAt := metav1.NewMicroTime(time.Now().Add(-900 * time.Millisecond))
+		board.Status.ElapsedTimeUpdatedAt = &updatedAt
+
+		g.Expect(moveCurrentMino(ctx, board, nil, 0, "drop", true)).To(Equal(t4sv1.ActionApplied))
+		g.Expect(board.Status.State).To(Equal(t4sv1.Cleared))
+		g.Expect(board.Status.ElapsedTime).To(BeNumerically("~", 10900, 50))
+		g.Expect(board.Status.ElapsedTimeUpdatedAt).To(BeNil())
+
+		t.Log("stopping the clock")
+		elapsed := board.Status.ElapsedTime
+		reconcileElapsedTime(board, time.Now().Add(time.Minute))
+		g.Expect(board.Status.ElapsedTime).To(Equal(elapsed))
+	})
+
+	t.Run("should clear the game when the time is up in Ultra mode", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newBoard(t4sv1.Ultra)
+		board.Status.Score = 1200
+		board.Status.ElapsedTime = 100000
+		g.Expect(reconcileGoal(ctx, board)).To(Equal(20 * time.Second))
+		g.Expect(board.Status.State).To(Equal(t4sv1.Playing))
+
+		updatedAt := metav1.NewMicroTime(time.Now())
+		board.Status.ElapsedTimeUpdatedAt = &updatedAt
+		board.Status.ElapsedTime = 120300
+		g.Expect(reconcileGoal(ctx, board)).To(BeZero())
+		g.Expect(board.Status.State).To(Equal(t4sv1.Cleared))
+		g.Expect(board.Status.StateReason).To(Equal(t4sv1.ReasonTimeUp))
+		g.Expect(board.Status.ElapsedTime).To(Equal(int64(120000)))
+		g.Expect(board.Status.Score).To(Equal(1200))
+
+		t.Log("stopping the clock")
+		reconcileElapsedTime(board, time.Now().Add(time.Minute))
+		g.Expect(board.Status.ElapsedTime).To(Equal(int64(120000)))
+	})
+}
+
+func TestBoardPuzzle(t *testing.T) {
+	ctx := context.Background()
+
+	minoes := []t4sv1.Mino{
+		{Spec: t4sv1.MinoSpec{MinoID: 1, Coords: testOCoords}},
+		{Spec: t4sv1.MinoSpec{MinoID: 2, Coords: testOCoords}},
+	}
+
+	newPuzzle := func(goal t4sv1.PuzzleGoal, data [][]int) *t4sv1.Board {
+		cells := make([][]int, len(data))
+		for y := range cells {
+			cells[y] = append([]int{}, data[y]...)
+		}
+		board := newTestBoard(cells)
+		board.Spec.NextCount = 3
+		board.Spec.Mode = t4sv1.Puzzle
+		board.Spec.PuzzleGoal = goal
+		board.Spec.GoalLines = 1
+		board.Spec.InitialData = data
+		board.Spec.Sequence = []int{2}
+		return board
+	}
+
+	t.Run("should clear the puzzle by clearing the lines", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newPuzzle(t4sv1.ClearLines, [][]int{{0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}, {1, 1, 0, 0}})
+		reconcileCurrentMino(ctx, board, minoes)
+		g.Expect(moveCurrentMino(ctx, board, minoes, 0, "right", true)).To(Equal(t4sv1.ActionApplied))
+		g.Expect(moveCurrentMino(ctx, board, minoes, 0, "drop", true)).To(Equal(t4sv1.ActionApplied))
+		g.Expect(board.Status.State).To(Equal(t4sv1.Cleared))
+		g.Expect(board.Status.StateReason).To(Equal(t4sv1.ReasonGoalReached))
+	})
+
+	t.Run("should clear the puzzle by clearing the board", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newPuzzle(t4sv1.ClearBoard, [][]int{{0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}, {1, 1, 0, 0}, {1, 1, 0, 0}})
+		reconcileCurrentMino(ctx, board, minoes)
+		moveCurrentMino(ctx, board, minoes, 0, "right", true)
+		moveCurrentMino(ctx, board, minoes, 0, "drop", true)
+		g.Expect(board.Status.State).To(Equal(t4sv1.Cleared))
+		g.Expect(board.Status.Lines).To(Equal(2))
+	})
+
+	t.Run("should fail the puzzle when the minoes run out", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newPuzzle(t4sv1.ClearBoard, [][]int{{0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}, {0, 0, 0, 0}, {1, 1, 0, 0}})
+		reconcileCurrentMino(ctx, board, minoes)
+		g.Expect(holdMino(ctx, board, minoes, 0)).To(Equal(t4sv1.ActionBlocked))
+		moveCurrentMino(ctx, board, minoes, 0, "right", true)
+		moveCurrentMino(ctx, board, minoes, 0, "drop", true)
+		g.Expect(board.Status.State).To(Equal(t4sv1.GameOver))
+		g.Expect(board.Status.StateReason).To(Equal(t4sv1.ReasonOutOfMinoes))
+	})
+}
+
+func TestBoardTSpin(t *testing.T) {
+	ctx := context.Background()
+
+	tSpec := t4sv1.MinoSpec{MinoID: 7, Coords: testTCoords}
+	newBoard := func(data [][]int, rotation int, center t4sv1.Coord, rotated bool) *t4sv1.Board {
+		return newTestBoard(data, t4sv1.CurrentMino{
+			MinoID:         tSpec.MinoID,
+			Center:         center,
+			RelativeCoords: boardCoords(gameSpec(tSpec).Shape(rotation)),
+			Rotation:       rotation,
+			Rotated:        rotated,
+		})
+	}
+	detectTSpin := func(board *t4sv1.Board) engine.TSpin {
+		return gameBoard(board).TSpin(gamePiece(board.Status.CurrentMino[0]))
+	}
+	// T mino pointing down into the slot, under an overhang
+	tSpinDouble := func(rotated bool) *t4sv1.Board {
+		return newBoard([][]int{
+			{0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 1, 0, 0},
+			{1, 0, 0, 0, 1, 1},
+			{1, 1, 0, 1, 1, 1},
+		}, 2, t4sv1.Coord{X: 2, Y: 3}, rotated)
+	}
+	// T mino pointing up on the floor, with one of the front corners occupied
+	tSpinMiniSingle := func() *t4sv1.Board {
+		return newBoard([][]int{
+			{0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0},
+			{1, 0, 0, 0, 0, 0},
+			{0, 0, 0, 1, 1, 1},
+		}, 0, t4sv1.Coord{X: 1, Y: 4}, true)
+	}
+
+	t.Run("should detect a T-spin double", func(t *testing.T) {
+		g := NewWithT(t)
+		board := tSpinDouble(true)
+		moveCurrentMino(ctx, board, nil, 0, "down", false)
+		g.Expect(board.Status.Data).To(Equal([][]int{
+			{0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 1, 0, 0},
+		}))
+		g.Expect(board.Status.LastClear).To(Equal(t4sv1.TSpinDouble))
+		g.Expect(board.Status.Score).To(Equal(1200))
+		g.Expect(board.Status.Lines).To(Equal(2))
+		g.Expect(board.Status.Combo).To(Equal(1))
+		g.Expect(board.Status.BackToBack).To(Equal(1))
+	})
+
+	t.Run("should not detect a T-spin unless the last move was a rotation", func(t *testing.T) {
+		g := NewWithT(t)
+		board := tSpinDouble(false)
+		moveCurrentMino(ctx, board, nil, 0, "down", false)
+		g.Expect(board.Status.LastClear).To(Equal(t4sv1.Double))
+		g.Expect(board.Status.Score).To(Equal(300))
+		g.Expect(board.Status.BackToBack).To(BeZero())
+	})
+
+	t.Run("should cancel the T-spin when the mino moves after the rotation", func(t *testing.T) {
+		g := NewWithT(t)
+		board := tSpinMiniSingle()
+		board.Status.Data[4][3] = 0
+		g.Expect(moveCurrentMino(ctx, board, nil, 0, "right", true)).To(Equal(t4sv1.ActionApplied))
+		g.Expect(board.Status.CurrentMino[0].Rotated).To(BeFalse())
+		g.Expect(detectTSpin(board)).To(Equal(engine.NoTSpin))
+	})
+
+	t.Run("should detect a T-spin single which removes a row in the middle", func(t *testing.T) {
+		g := NewWithT(t)
+		board := tSpinDouble(true)
+		board.Status.Data[4][5] = 0
+		moveCurrentMino(ctx, board, nil, 0, "down", false)
+		g.Expect(board.Status.Data).To(Equal([][]int{
+			{0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 0, 0, 0},
+			{0, 0, 0, 1, 0, 0},
+			{1, 1, 7, 1, 1, 0},
+		}))
+		g.Expect(board.Status.LastClear).To(Equal(t4sv1.TSpinSingle))
+		g.Expect(board.Status.ClearedRows).To(Equal([]int{3}))
+	})
+
+	t.Run("should detect a T-spin mini", func(t *testing.T) {
+		g := NewWithT(t)
+		board := tSpinMiniSingle()
+		g.Expect(detectTSpin(board)).To(Equal(engine.TSpinMini))
+		moveCurrentMino(ctx, board, nil, 0, "down", false)
+		g.Expect(board.Status.LastClear).To(Equal(t4sv1.TSpinMiniSingle))
+		g.Expect(board.Status.Score).To(Equal(200))
+	})
+
+	t.Run("should upgrade a T-spin mini made with the last wall kick", func(t *testing.T) {
+		g := NewWithT(t)
+		board := tSpinMiniSingle()
+		board.Status.CurrentMino[0].KickIndex = engine.TSpinUpgradeKick
+		g.Expect(detectTSpin(board)).To(Equal(engine.TSpinFull))
+	})
+
+	t.Run("should detect a T-spin without lines", func(t *testing.T) {
+		g := NewWithT(t)
+		board := tSpinDouble(true)
+		board.Status.Data[3][0] = 0
+		board.Status.Data[4][5] = 0
+		board.Status.Combo = 2
+		moveCurrentMino(ctx, board, nil, 0, "down", false)
+		g.Expect(board.Status.ClearedRows).To(BeEmpty())
+		g.Expect(board.Status.LastClear).To(Equal(t4sv1.TSpin))
+		g.Expect(board.Status.Score).To(Equal(400))
+		g.Expect(board.Status.Combo).To(BeZero())
+	})
+
+	t.Run("should not detect a T-spin with other minoes", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(detectTSpin(tSpinDouble(true))).To(Equal(engine.TSpinFull))
+		board := tSpinDouble(true)
+		board.Status.CurrentMino[0].RelativeCoords = []t4sv1.Coord{{X: -1, Y: 1}, {X: -1, Y: 0}, {X: 0, Y: 0}, {X: 1, Y: 0}}
+		g.Expect(detectTSpin(board)).To(Equal(engine.NoTSpin))
+	})
+
+	t.Run("should add the back-to-back bonus", func(t *testing.T) {
+		g := NewWithT(t)
+		board := tSpinDouble(true)
+		board.Status.BackToBack = 1
+		moveCurrentMino(ctx, board, nil, 0, "down", false)
+		g.Expect(board.Status.Score).To(Equal(1800))
+		g.Expect(board.Status.BackToBack).To(Equal(2))
+	})
+
+	t.Run("should add the combo bonus and break the back-to-back chain", func(t *testing.T) {
+		g := NewWithT(t)
+		board := tSpinDouble(false)
+		board.Status.Combo = 2
+		board.Status.BackToBack = 3
+		moveCurrentMino(ctx, board, nil, 0, "down", false)
+		g.Expect(board.Status.Combo).To(Equal(3))
+		g.Expect(board.Status.Score).To(Equal(300 + 2*engine.ComboScore))
+		g.Expect(board.Status.BackToBack).To(BeZero())
+	})
+}
+
+func TestBoardVersus(t *testing.T) {
+	ctx := context.Background()
+
+	newBoard := func(name string, opponents ...string) *t4sv1.Board {
+		board := newTestBoard(emptyData(4, 5))
+		board.ObjectMeta = metav1.ObjectMeta{Namespace: "default", Name: name}
+		board.Spec.Opponents = opponents
+		board.Status.Seed = 1
+		return board
+	}
+	newReconciler := func(boards ...*t4sv1.Board) *BoardReconciler {
+		var objs []client.Object
+		for _, board := range boards {
+			objs = append(objs, board)
+		}
+		return newTestReconciler(t, objs...)
+	}
+
+	t.Run("should push the pending garbage rows when a mino is fixed without removing rows", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newBoard("a", "b")
+		board.Status.PendingGarbage = 1
+		mino := t4sv1.CurrentMino{MinoID: 2, Center: t4sv1.Coord{X: 0, Y: 4}, RelativeCoords: testOCoords}
+		setAbsoluteCoords(&mino)
+		board.Status.CurrentMino = []t4sv1.CurrentMino{mino}
+		landCurrentMino(ctx, board, 0)
+		g.Expect(board.Status.PendingGarbage).To(BeZero())
+		g.Expect(board.Status.Data[2][:2]).To(Equal([]int{2, 2}))
+		g.Expect(board.Status.Data[3][:2]).To(Equal([]int{2, 2}))
+		g.Expect(board.Status.Data[4]).To(ContainElement(t4sv1.GarbageCell))
+	})
+
+	t.Run("should top out when the garbage rows push the blocks out of the top", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newBoard("a", "b")
+		board.Status.PendingGarbage = 4
+		mino := t4sv1.CurrentMino{MinoID: 2, Center: t4sv1.Coord{X: 0, Y: 4}, RelativeCoords: testOCoords}
+		setAbsoluteCoords(&mino)
+		board.Status.CurrentMino = []t4sv1.CurrentMino{mino}
+		landCurrentMino(ctx, board, 0)
+		g.Expect(board.Status.State).To(Equal(t4sv1.GameOver))
+		g.Expect(board.Status.StateReason).To(Equal(t4sv1.ReasonToppedOut))
+		g.Expect(board.Status.CurrentMino).To(BeEmpty())
+	})
+
+	t.Run("should receive the garbage rows sent after the Board is created", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newBoard("a", "b")
+		opponent := newBoard("b", "a")
+		opponent.Status.GarbageSent = 5
+		r := newReconciler(opponent)
+
+		g.Expect(r.reconcileVersus(ctx, board)).To(BeZero())
+		g.Expect(board.Status.PendingGarbage).To(BeZero())
+		g.Expect(board.Status.GarbageReceived).To(Equal(map[string]int{"b": 5}))
+
+		opponent.Status.GarbageSent = 7
+		g.Expect(r.Status().Update(ctx, opponent)).To(Succeed())
+		g.Expect(r.reconcileVersus(ctx, board)).To(BeZero())
+		g.Expect(board.Status.PendingGarbage).To(Equal(2))
+		g.Expect(board.Status.GarbageReceived).To(Equal(map[string]int{"b": 7}))
+	})
+
+	t.Run("should decide the winner when all but one player has topped out", func(t *testing.T) {
+		g := NewWithT(t)
+		a := newBoard("a", "b", "c")
+		b := newBoard("b", "a", "c")
+		c := newBoard("c", "a", "b")
+		b.Status.State = t4sv1.GameOver
+		r := newReconciler(a, b, c)
+
+		t.Log("waiting for the other players")
+		g.Expect(r.reconcileVersus(ctx, a)).To(BeZero())
+		g.Expect(a.Status.Winner).To(BeEmpty())
+		g.Expect(r.reconcileVersus(ctx, b)).To(Equal(versusPollInterval))
+		g.Expect(b.Status.Winner).To(BeEmpty())
+
+		t.Log("topping out the last opponent")
+		c.Status.State = t4sv1.GameOver
+		g.Expect(r.Status().Update(ctx, c)).To(Succeed())
+		g.Expect(r.reconcileVersus(ctx, a)).To(BeZero())
+		g.Expect(a.Status.Winner).To(Equal("a"))
+		g.Expect(a.Status.State).To(Equal(t4sv1.Cleared))
+		g.Expect(a.Status.StateReason).To(Equal(t4sv1.ReasonWon))
+
+		t.Log("recording the winner on the loser")
+		g.Expect(r.Status().Update(ctx, a)).To(Succeed())
+		g.Expect(r.reconcileVersus(ctx, b)).To(BeZero())
+		g.Expect(b.Status.Winner).To(Equal("a"))
+		g.Expect(b.Status.State).To(Equal(t4sv1.GameOver))
+	})
+
+	t.Run("should not decide the winner while an opponent is not found", func(t *testing.T) {
+		g := NewWithT(t)
+		a := newBoard("a", "b", "c")
+		b := newBoard("b", "a", "c")
+		a.Status.State = t4sv1.GameOver
+		b.Status.State = t4sv1.GameOver
+		r := newReconciler(a, b)
+
+		g.Expect(r.reconcileVersus(ctx, a)).To(Equal(versusPollInterval))
+		g.Expect(a.Status.Winner).To(BeEmpty())
+
+		t.Log("finding the opponent again")
+		c := newBoard("c", "a", "b")
+		g.Expect(r.Create(ctx, c)).To(Succeed())
+		g.Expect(r.reconcileVersus(ctx, a)).To(BeZero())
+		g.Expect(a.Status.Winner).To(Equal("c"))
+	})
+}
+
+func TestBoardCoOp(t *testing.T) {
+	ctx := context.Background()
+
+	minoes := []t4sv1.Mino{{Spec: t4sv1.MinoSpec{MinoID: 2, Coords: testOCoords}}}
+	newBoard := func(players ...string) *t4sv1.Board {
+		board := newTestBoard(emptyData(8, 6))
+		board.Spec.NextCount = 1
+		board.Spec.Players = players
+		reconcileCurrentMino(ctx, board, minoes)
+		return board
+	}
+	action := func(op, player string) t4sv1.Action {
+		return t4sv1.Action{Spec: t4sv1.ActionSpec{Op: op, Player: player}}
+	}
+
+	t.Run("should put a current mino for each player", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newBoard("p1", "p2")
+		g.Expect(board.Status.CurrentMino).To(HaveLen(2))
+		g.Expect(board.Status.CurrentMino[0].Player).To(Equal("p1"))
+		g.Expect(board.Status.CurrentMino[0].Center).To(Equal(t4sv1.Coord{X: 1, Y: 2}))
+		g.Expect(board.Status.CurrentMino[1].Player).To(Equal("p2"))
+		g.Expect(board.Status.CurrentMino[1].Center).To(Equal(t4sv1.Coord{X: 5, Y: 2}))
+
+		t.Log("removing a player")
+		board.Spec.Players = []string{"p2"}
+		reconcileCurrentMino(ctx, board, minoes)
+		g.Expect(board.Status.CurrentMino).To(HaveLen(1))
+		g.Expect(board.Status.CurrentMino[0].Player).To(Equal("p2"))
+	})
+
+	t.Run("should move only the mino of the player", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newBoard("p1", "p2")
+		g.Expect(applyAction(ctx, board, minoes, action("left", "p2"))).To(Equal(t4sv1.ActionApplied))
+		g.Expect(board.Status.CurrentMino[0].Center).To(Equal(t4sv1.Coord{X: 1, Y: 2}))
+		g.Expect(board.Status.CurrentMino[1].Center).To(Equal(t4sv1.Coord{X: 4, Y: 2}))
+		g.Expect(applyAction(ctx, board, minoes, action("left", "p3"))).To(Equal(t4sv1.ActionIgnored))
+
+		t.Log("moving all the minoes without a player")
+		g.Expect(applyAction(ctx, board, minoes, action("down", ""))).To(Equal(t4sv1.ActionApplied))
+		g.Expect(board.Status.CurrentMino[0].Center.Y).To(Equal(3))
+		g.Expect(board.Status.CurrentMino[1].Center.Y).To(Equal(3))
+	})
+
+	t.Run("should block the minoes by each other", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newBoard("p1", "p2")
+		g.Expect(applyAction(ctx, board, minoes, action("right", "p1"))).To(Equal(t4sv1.ActionApplied))
+		g.Expect(applyAction(ctx, board, minoes, action("right", "p1"))).To(Equal(t4sv1.ActionApplied))
+		g.Expect(applyAction(ctx, board, minoes, action("right", "p1"))).To(Equal(t4sv1.ActionBlocked))
+
+		t.Log("dropping a mino")
+		g.Expect(applyAction(ctx, board, minoes, action("drop", "p2"))).To(Equal(t4sv1.ActionApplied))
+		g.Expect(board.Status.Data[5][5:7]).To(Equal([]int{2, 2}))
+		g.Expect(board.Status.CurrentMino).To(HaveLen(2))
+		g.Expect(board.Status.CurrentMino[1].Player).To(Equal("p2"))
+
+		t.Log("moving a mino down onto the other one")
+		board.Status.CurrentMino[0].Center = t4sv1.Coord{X: 1, Y: 2}
+		setAbsoluteCoords(&board.Status.CurrentMino[0])
+		board.Status.CurrentMino[1].Center = t4sv1.Coord{X: 1, Y: 4}
+		setAbsoluteCoords(&board.Status.CurrentMino[1])
+		g.Expect(applyAction(ctx, board, minoes, action("down", "p1"))).To(Equal(t4sv1.ActionBlocked))
+		g.Expect(board.Status.CurrentMino).To(HaveLen(2))
+		g.Expect(board.Status.CurrentMino[0].LockStartTime).To(BeNil())
+		g.Expect(newGame(board, nil).Ghost(0).Center).To(Equal(engine.Coord{X: 1, Y: 2}))
+	})
+
+	t.Run("should wait for the teammate's mino to move away before the next mino appears", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newBoard("p1", "p2")
+		// p1 moves into the place where the next mino of p2 appears
+		board.Status.CurrentMino[0].Center = t4sv1.Coord{X: 5, Y: 2}
+		setAbsoluteCoords(&board.Status.CurrentMino[0])
+		board.Status.CurrentMino = board.Status.CurrentMino[:1]
+		next := append([]int{}, board.Status.Next...)
+		reconcileCurrentMino(ctx, board, minoes)
+		g.Expect(board.Status.State).To(Equal(t4sv1.Playing))
+		g.Expect(board.Status.CurrentMino).To(HaveLen(1))
+		g.Expect(board.Status.Next[:len(next)]).To(Equal(next))
+
+		applyAction(ctx, board, minoes, action("left", "p1"))
+		applyAction(ctx, board, minoes, action("left", "p1"))
+		reconcileCurrentMino(ctx, board, minoes)
+		g.Expect(board.Status.CurrentMino).To(HaveLen(2))
+	})
+
+	// moveMino puts the current mino of the player at the center.
+	moveMino := func(board *t4sv1.Board, player string, center t4sv1.Coord) {
+		i := findCurrentMino(board, player)
+		board.Status.CurrentMino[i].Center = center
+		setAbsoluteCoords(&board.Status.CurrentMino[i])
+	}
+
+	t.Run("should not use the hold when the held mino is blocked by the teammate's mino", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newBoard("p1", "p2")
+		board.Status.HoldMino = 2
+		// p2 moves into the place where the held mino of p1 appears
+		moveMino(board, "p1", t4sv1.Coord{X: 1, Y: 5})
+		moveMino(board, "p2", t4sv1.Coord{X: 1, Y: 2})
+		current := board.Status.CurrentMino[0]
+
+		g.Expect(applyAction(ctx, board, minoes, action("hold", "p1"))).To(Equal(t4sv1.ActionBlocked))
+		g.Expect(board.Status.HoldMino).To(Equal(2))
+		g.Expect(board.Status.CurrentMino[0]).To(Equal(current))
+		g.Expect(board.Status.CurrentMino[0].HoldUsed).To(BeFalse())
+	})
+
+	t.Run("should not use the hold when the next mino is blocked by the teammate's mino", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newBoard("p1", "p2")
+		moveMino(board, "p1", t4sv1.Coord{X: 1, Y: 5})
+		moveMino(board, "p2", t4sv1.Coord{X: 1, Y: 2})
+		current := board.Status.CurrentMino[0]
+		next := append([]int{}, board.Status.Next...)
+
+		g.Expect(applyAction(ctx, board, minoes, action("hold", "p1"))).To(Equal(t4sv1.ActionBlocked))
+		g.Expect(board.Status.HoldMino).To(BeZero())
+		g.Expect(board.Status.CurrentMino).To(HaveLen(2))
+		g.Expect(board.Status.CurrentMino[0]).To(Equal(current))
+		g.Expect(board.Status.Next[:len(next)]).To(Equal(next))
+
+		t.Log("holding after the teammate's mino moves away")
+		moveMino(board, "p2", t4sv1.Coord{X: 5, Y: 2})
+		g.Expect(applyAction(ctx, board, minoes, action("hold", "p1"))).To(Equal(t4sv1.ActionApplied))
+		g.Expect(board.Status.HoldMino).To(Equal(2))
+		g.Expect(board.Status.CurrentMino[findCurrentMino(board, "p1")].HoldUsed).To(BeTrue())
+	})
+
+	t.Run("should keep the teammate's mino when the minoes in the sequence run out", func(t *testing.T) {
+		g := NewWithT(t)
+		board := newTestBoard(emptyData(8, 6))
+		board.Spec.Players = []string{"p1", "p2"}
+		board.Spec.Sequence = []int{2}
+		reconcileCurrentMino(ctx, board, minoes)
+		g.Expect(board.Status.State).To(Equal(t4sv1.Playing))
+		g.Expect(board.Status.CurrentMino).To(HaveLen(1))
+		g.Expect(board.Status.CurrentMino[0].Player).To(Equal("p1"))
+
+		t.Log("landing the last mino")
+		g.Expect(applyAction(ctx, board, minoes, action("drop", "p1"))).To(Equal(t4sv1.ActionApplied))
+		reconcileCurrentMino(ctx, board, minoes)
+		g.Expect(board.Status.State).To(Equal(t4sv1.GameOver))
+		g.Expect(board.Status.StateReason).To(Equal(t4sv1.ReasonOutOfMinoes))
+	})
+}
@@ -28,6 +28,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	t4sv1 "github.com/tkna/t4s/api/v1"
+	"github.com/tkna/t4s/pkg/constants"
 )
 
 // CronReconciler reconciles a Cron object.
@@ -60,13 +61,23 @@ func (r *CronReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 		return ctrl.Result{}, nil
 	}
 
+	if cron.Spec.Suspend {
+		logger.Info("Cron is suspended")
+		return ctrl.Result{}, nil
+	}
+
 	action := t4sv1.Action{
 		ObjectMeta: metav1.ObjectMeta{
 			Namespace:    cron.Namespace,
 			GenerateName: "action-",
+			Labels: map[string]string{
+				constants.ActionSourceLabel: constants.ActionSourceCron,
+				constants.ActionBoardLabel:  boardName(cron),
+			},
 		},
 		Spec: t4sv1.ActionSpec{
-			Op: "down",
+			Op:       "down",
+			Sequence: t4sv1.NewActionSequence(),
 		},
 	}
 	action.SetOwnerReferences(cron.GetOwnerReferences())
@@ -81,6 +92,14 @@ func (r *CronReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.
 	return ctrl.Result{RequeueAfter: time.Millisecond * time.Duration(cron.Spec.Period)}, nil
 }
 
+// boardName returns the name of the Board which owns the Cron.
+func boardName(cron t4sv1.Cron) string {
+	if owner := metav1.GetControllerOf(&cron); owner != nil {
+		return owner.Name
+	}
+	return cron.Name
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *CronReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
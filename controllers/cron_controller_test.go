@@ -14,6 +14,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	t4sv1 "github.com/tkna/t4s/api/v1"
+	"github.com/tkna/t4s/pkg/constants"
 )
 
 var _ = Describe("Cron controller", func() {
@@ -87,6 +88,9 @@ var _ = Describe("Cron controller", func() {
 			if actions.Items[0].Spec.Op != "down" {
 				return fmt.Errorf("actions.Items[0].Spec.Op != 'down'")
 			}
+			if actions.Items[0].Labels[constants.ActionBoardLabel] != "cron" {
+				return fmt.Errorf("actions.Items[0] is not labeled with the board name")
+			}
 			return nil
 		}).Should(Succeed())
 
@@ -109,4 +113,56 @@ var _ = Describe("Cron controller", func() {
 		err = k8sClient.Delete(ctx, cron)
 		Expect(err).ShouldNot(HaveOccurred())
 	})
+
+	It("should not create Actions while the Cron is suspended", func() {
+		By("creating a namespace and a suspended Cron")
+		nsName := "test-ns-cron-suspend"
+		ns := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: nsName,
+			},
+		}
+		err := k8sClient.Create(ctx, ns)
+		Expect(err).NotTo(HaveOccurred())
+
+		cron := &t4sv1.Cron{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: nsName,
+				Name:      "cron",
+			},
+			Spec: t4sv1.CronSpec{
+				Period:  500,
+				Suspend: true,
+			},
+		}
+		err = k8sClient.Create(ctx, cron)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		By("checking Action will NOT be created")
+		actions := &t4sv1.ActionList{}
+		Consistently(func() error {
+			if err := k8sClient.List(ctx, actions, &client.ListOptions{Namespace: nsName}); err != nil {
+				return err
+			}
+			if len(actions.Items) != 0 {
+				return fmt.Errorf("Action is created by the suspended Cron")
+			}
+			return nil
+		}, 2*time.Second).Should(Succeed())
+
+		By("resuming the Cron")
+		cron.Spec.Suspend = false
+		err = k8sClient.Update(ctx, cron)
+		Expect(err).ShouldNot(HaveOccurred())
+
+		Eventually(func() error {
+			if err := k8sClient.List(ctx, actions, &client.ListOptions{Namespace: nsName}); err != nil {
+				return err
+			}
+			if len(actions.Items) == 0 {
+				return fmt.Errorf("number of actions is 0")
+			}
+			return nil
+		}).Should(Succeed())
+	})
 })
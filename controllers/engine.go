@@ -0,0 +1,191 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	t4sv1 "github.com/tkna/t4s/api/v1"
+	"github.com/tkna/t4s/pkg/engine"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// newGame returns the engine game of the board, which can deal the minoes.
+// The board of the game is backed by board.Status.Data, so setGame must be called after the game is changed.
+func newGame(board *t4sv1.Board, minoes []t4sv1.Mino) *engine.Game {
+	game := &engine.Game{
+		Board:          gameBoard(board),
+		Players:        board.Spec.Players,
+		NextCount:      board.Spec.NextCount,
+		Randomizer:     engine.Randomizer(board.Spec.Randomizer),
+		Sequence:       board.Spec.Sequence,
+		LockDelay:      time.Duration(board.Spec.LockDelay) * time.Millisecond,
+		MaxLockResets:  board.Spec.MaxLockResets,
+		Versus:         len(board.Spec.Opponents) != 0,
+		Held:           board.Status.HoldMino,
+		Next:           board.Status.Next,
+		Bag:            board.Status.Bag,
+		SequenceIndex:  board.Status.SequenceIndex,
+		RandomState:    board.Status.RandomState,
+		Score:          board.Status.Score,
+		Lines:          board.Status.Lines,
+		Level:          board.Status.Level,
+		Pieces:         board.Status.Pieces,
+		Combo:          board.Status.Combo,
+		BackToBack:     board.Status.BackToBack,
+		LastClear:      engine.ClearType(board.Status.LastClear),
+		ClearedRows:    board.Status.ClearedRows,
+		PendingGarbage: board.Status.PendingGarbage,
+		GarbageSent:    board.Status.GarbageSent,
+	}
+	for _, mino := range minoes {
+		game.Minoes = append(game.Minoes, engine.Mino{ID: mino.Spec.MinoID, Spec: gameSpec(mino.Spec)})
+	}
+	for _, mino := range board.Status.CurrentMino {
+		game.Current = append(game.Current, gameCurrent(mino))
+	}
+	return game
+}
+
+// setGame writes the engine game back to the status of the board.
+// If the game is over, the state of the board is set to GameOver with the reason.
+func setGame(board *t4sv1.Board, game *engine.Game) {
+	board.Status.Data = game.Board.Cells
+	board.Status.HoldMino = game.Held
+	board.Status.Next = game.Next
+	board.Status.Bag = game.Bag
+	board.Status.SequenceIndex = game.SequenceIndex
+	board.Status.RandomState = game.RandomState
+	board.Status.Score = game.Score
+	board.Status.Lines = game.Lines
+	board.Status.Level = game.Level
+	board.Status.Pieces = game.Pieces
+	board.Status.Combo = game.Combo
+	board.Status.BackToBack = game.BackToBack
+	board.Status.LastClear = t4sv1.ClearType(game.LastClear)
+	board.Status.ClearedRows = game.ClearedRows
+	board.Status.PendingGarbage = game.PendingGarbage
+	board.Status.GarbageSent = game.GarbageSent
+
+	board.Status.CurrentMino = nil
+	for _, c := range game.Current {
+		board.Status.CurrentMino = append(board.Status.CurrentMino, boardCurrent(c))
+	}
+
+	switch game.End {
+	case engine.NoSpace:
+		board.Status.State = t4sv1.GameOver
+	case engine.OutOfMinoes:
+		board.Status.State = t4sv1.GameOver
+		board.Status.StateReason = t4sv1.ReasonOutOfMinoes
+	case engine.ToppedOut:
+		board.Status.State = t4sv1.GameOver
+		board.Status.StateReason = t4sv1.ReasonToppedOut
+	}
+}
+
+// gameBoard returns the engine board backed by board.Status.Data. The changes to the engine board are made to the Board directly.
+func gameBoard(board *t4sv1.Board) engine.Board {
+	return engine.Board{
+		Width:  board.Spec.Width,
+		Height: board.Spec.Height,
+		Cells:  board.Status.Data,
+	}
+}
+
+// gameCurrent returns the engine falling piece of the current mino.
+func gameCurrent(mino t4sv1.CurrentMino) engine.Current {
+	c := engine.Current{
+		MinoID:     mino.MinoID,
+		Player:     mino.Player,
+		Piece:      gamePiece(mino),
+		LockResets: mino.LockResets,
+		HoldUsed:   mino.HoldUsed,
+	}
+	if mino.LockStartTime != nil {
+		c.LockStart = mino.LockStartTime.Time
+	}
+	return c
+}
+
+// boardCurrent returns the current mino of the engine falling piece.
+func boardCurrent(c engine.Current) t4sv1.CurrentMino {
+	mino := t4sv1.CurrentMino{
+		MinoID:     c.MinoID,
+		Player:     c.Player,
+		LockResets: c.LockResets,
+		HoldUsed:   c.HoldUsed,
+	}
+	setPiece(&mino, c.Piece)
+	if !c.LockStart.IsZero() {
+		lockStart := metav1.NewMicroTime(c.LockStart)
+		mino.LockStartTime = &lockStart
+	}
+	return mino
+}
+
+// gamePiece returns the engine piece of the current mino.
+func gamePiece(mino t4sv1.CurrentMino) engine.Piece {
+	return engine.Piece{
+		Center:    engine.Coord(mino.Center),
+		Shape:     gameCoords(mino.RelativeCoords),
+		Rotation:  mino.Rotation,
+		Rotated:   mino.Rotated,
+		KickIndex: mino.KickIndex,
+	}
+}
+
+// setPiece updates the position of the current mino to the engine piece.
+func setPiece(mino *t4sv1.CurrentMino, p engine.Piece) {
+	mino.Center = t4sv1.Coord(p.Center)
+	mino.RelativeCoords = boardCoords(p.Shape)
+	mino.AbsoluteCoords = boardCoords(p.Cells())
+	mino.Rotation = p.Rotation
+	mino.Rotated = p.Rotated
+	mino.KickIndex = p.KickIndex
+}
+
+// gameSpec returns the engine spec of the mino.
+func gameSpec(spec t4sv1.MinoSpec) engine.Spec {
+	s := engine.Spec{
+		Coords:    gameCoords(spec.Coords),
+		KickTable: engine.KickTable(spec.KickTable),
+	}
+	for _, coords := range spec.Rotations {
+		s.Rotations = append(s.Rotations, gameCoords(coords))
+	}
+	for _, kick := range spec.Kicks {
+		s.Kicks = append(s.Kicks, engine.Kick{From: kick.From, To: kick.To, Offsets: gameCoords(kick.Offsets)})
+	}
+	return s
+}
+
+func gameCoords(coords []t4sv1.Coord) []engine.Coord {
+	var cs []engine.Coord
+	for _, c := range coords {
+		cs = append(cs, engine.Coord(c))
+	}
+	return cs
+}
+
+func boardCoords(coords []engine.Coord) []t4sv1.Coord {
+	var cs []t4sv1.Coord
+	for _, c := range coords {
+		cs = append(cs, t4sv1.Coord(c))
+	}
+	return cs
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	t4sv1 "github.com/tkna/t4s/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcileIdleTimeout stops the game when no Action has been requested by the user for board.Spec.IdleTimeoutSeconds.
+// It returns the time left until then, so that the Board is reconciled again even if no Action comes.
+func reconcileIdleTimeout(ctx context.Context, board *t4sv1.Board, now time.Time) time.Duration {
+	logger := log.FromContext(ctx)
+
+	if board.Status.State != t4sv1.Playing {
+		return 0
+	}
+	if board.Status.LastUserActionTime == nil {
+		t := metav1.NewMicroTime(now)
+		board.Status.LastUserActionTime = &t
+	}
+	if board.Spec.IdleTimeoutSeconds <= 0 {
+		return 0
+	}
+
+	deadline := board.Status.LastUserActionTime.Add(time.Duration(board.Spec.IdleTimeoutSeconds) * time.Second)
+	if remaining := deadline.Sub(now); remaining > 0 {
+		return remaining
+	}
+
+	state := t4sv1.Paused
+	if board.Spec.IdleState == t4sv1.IdleGameOver {
+		state = t4sv1.GameOver
+	}
+	logger.Info("idle timeout. stopping the game", "state", state)
+	board.Status.State = state
+	board.Status.StateReason = t4sv1.ReasonIdleTimeout
+	stopLockDelay(board)
+	return 0
+}
+
+// isIdlePaused returns true if the game has been paused by the idle timeout.
+func isIdlePaused(board *t4sv1.Board) bool {
+	return board.Status.State == t4sv1.Paused && board.Status.StateReason == t4sv1.ReasonIdleTimeout
+}
@@ -0,0 +1,82 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	t4sv1 "github.com/tkna/t4s/api/v1"
+	"github.com/tkna/t4s/pkg/engine"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// stopLockDelay clears the lock delay of all the current minoes. It starts over when they move down.
+func stopLockDelay(board *t4sv1.Board) {
+	game := newGame(board, nil)
+	game.StopLockDelay()
+	setGame(board, game)
+}
+
+// landCurrentMino fixes the i-th current mino on the board and removes the completed rows.
+func landCurrentMino(ctx context.Context, board *t4sv1.Board, i int) {
+	game := newGame(board, nil)
+	landing := game.Land(i)
+	setGame(board, game)
+	recordLanding(ctx, board, landing)
+}
+
+// recordLanding logs the landing of a mino and records the removed rows and the line clear in the metrics.
+// It finishes the game before the next mino appears if the goal is reached.
+func recordLanding(ctx context.Context, board *t4sv1.Board, landing engine.Landing) {
+	logger := log.FromContext(ctx)
+
+	if landing.Rows > 0 {
+		logger.Info("rows removed", "removed rows", landing.Rows)
+		RemovedRowsVec.WithLabelValues(board.Namespace, board.Name).Observe(float64(landing.Rows))
+	}
+	if landing.Clear != "" {
+		logger.Info("line clear", "type", landing.Clear, "combo", board.Status.Combo, "backToBack", board.Status.BackToBack)
+		LineClearsVec.WithLabelValues(board.Namespace, board.Name, string(landing.Clear)).Inc()
+	}
+	logger.Info("CurrentMino landed successfully", "player", landing.Player)
+
+	if board.Status.State == t4sv1.GameOver {
+		logger.Info("topped out by garbage rows. game over")
+		return
+	}
+	reconcileGoal(ctx, board)
+}
+
+// reconcileLockDelay fixes the current minoes whose lock delay is over.
+// It returns the time left until the next one, so that the Board is reconciled again without waiting for the next Action.
+func reconcileLockDelay(ctx context.Context, board *t4sv1.Board) time.Duration {
+	if board.Status.State != t4sv1.Playing {
+		return 0
+	}
+	game := newGame(board, nil)
+	landings, requeueAfter := game.Lock(time.Now())
+	setGame(board, game)
+	for _, landing := range landings {
+		log.FromContext(ctx).Info("lock delay is over", "player", landing.Player)
+		recordLanding(ctx, board, landing)
+	}
+	if board.Status.State != t4sv1.Playing {
+		return 0
+	}
+	return requeueAfter
+}
@@ -11,9 +11,15 @@ var (
 			Name:    "removed_rows_total",
 			Help:    "Number of removed rows",
 			Buckets: prometheus.LinearBuckets(1, 1, 4),
-		}, []string{"namespace"})
+		}, []string{"namespace", "board"})
+
+	LineClearsVec = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "line_clears_total",
+			Help: "Number of line clears and T-spins by type",
+		}, []string{"namespace", "board", "type"})
 )
 
 func init() {
-	metrics.Registry.MustRegister(RemovedRowsVec)
+	metrics.Registry.MustRegister(RemovedRowsVec, LineClearsVec)
 }
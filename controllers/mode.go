@@ -0,0 +1,88 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	t4sv1 "github.com/tkna/t4s/api/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcileGoal clears the game when the goal of board.Spec.Mode is reached.
+// It returns the time left until the time limit in Ultra mode, so that the game is finished even if no Action comes.
+func reconcileGoal(ctx context.Context, board *t4sv1.Board) time.Duration {
+	logger := log.FromContext(ctx)
+
+	if board.Status.State != t4sv1.Playing {
+		return 0
+	}
+
+	switch board.Spec.Mode {
+	case t4sv1.Sprint:
+		if board.Status.Lines < board.Spec.GoalLines {
+			return 0
+		}
+		logger.Info("goal lines cleared", "lines", board.Status.Lines, "elapsedTime", board.Status.ElapsedTime)
+		clearGame(board, t4sv1.ReasonGoalReached)
+	case t4sv1.Ultra:
+		limit := time.Duration(board.Spec.TimeLimitSeconds) * time.Second
+		if remaining := limit - time.Duration(board.Status.ElapsedTime)*time.Millisecond; remaining > 0 {
+			return remaining
+		}
+		logger.Info("time is up", "score", board.Status.Score)
+		// The play time is the time limit even if the clock has run over it
+		board.Status.ElapsedTime = limit.Milliseconds()
+		board.Status.ElapsedTimeUpdatedAt = nil
+		clearGame(board, t4sv1.ReasonTimeUp)
+	case t4sv1.Puzzle:
+		if !isPuzzleSolved(board) {
+			return 0
+		}
+		logger.Info("puzzle solved", "pieces", board.Status.Pieces)
+		clearGame(board, t4sv1.ReasonGoalReached)
+	}
+	return 0
+}
+
+// isPuzzleSolved returns true if board.Spec.PuzzleGoal is reached.
+func isPuzzleSolved(board *t4sv1.Board) bool {
+	if board.Spec.PuzzleGoal != t4sv1.ClearBoard {
+		return board.Status.Lines >= board.Spec.GoalLines
+	}
+	// The board is empty before the first mino is placed
+	if board.Status.Pieces == 0 {
+		return false
+	}
+	for _, row := range board.Status.Data {
+		for _, cell := range row {
+			if cell != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// clearGame finishes the game with the state Cleared. The clock is stopped at once, counting the time since it was last updated.
+func clearGame(board *t4sv1.Board, reason string) {
+	board.Status.State = t4sv1.Cleared
+	board.Status.StateReason = reason
+	reconcileElapsedTime(board, time.Now())
+	board.Status.CurrentMino = nil
+}
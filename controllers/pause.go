@@ -0,0 +1,62 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	t4sv1 "github.com/tkna/t4s/api/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// reconcileState pauses or resumes the game following board.Spec.State.
+// A game which is over cannot be resumed; a new Board is created for a new game.
+func reconcileState(ctx context.Context, board *t4sv1.Board) {
+	logger := log.FromContext(ctx)
+
+	switch {
+	case board.Status.State == t4sv1.Playing && board.Spec.State == t4sv1.Paused:
+		logger.Info("pause the game")
+		board.Status.State = t4sv1.Paused
+		// The lock delay starts over when the mino moves down after the game is resumed
+		stopLockDelay(board)
+	case board.Status.State == t4sv1.Paused && board.Spec.State == t4sv1.Playing:
+		logger.Info("resume the game")
+		board.Status.State = t4sv1.Playing
+		board.Status.StateReason = ""
+		// The idle timeout starts over
+		board.Status.LastUserActionTime = nil
+	}
+}
+
+// reconcileElapsedTime adds the play time since the last update to board.Status.ElapsedTime.
+// The clock runs only while the game is being played.
+func reconcileElapsedTime(board *t4sv1.Board, now time.Time) {
+	if board.Status.ElapsedTimeUpdatedAt != nil {
+		elapsed := now.Sub(board.Status.ElapsedTimeUpdatedAt.Time).Milliseconds()
+		board.Status.ElapsedTime += elapsed
+		// Carry the fraction of a millisec over to the next update
+		now = board.Status.ElapsedTimeUpdatedAt.Add(time.Duration(elapsed) * time.Millisecond)
+		board.Status.ElapsedTimeUpdatedAt = nil
+	}
+	if board.Status.State == t4sv1.Playing {
+		updatedAt := metav1.NewMicroTime(now)
+		board.Status.ElapsedTimeUpdatedAt = &updatedAt
+	}
+}
@@ -0,0 +1,33 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"time"
+
+	t4sv1 "github.com/tkna/t4s/api/v1"
+)
+
+// initSeed records the seed of the game in the status. It is generated from the current time if not specified.
+func initSeed(board *t4sv1.Board) {
+	seed := board.Spec.Seed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	board.Status.Seed = seed
+	board.Status.RandomState = seed
+}
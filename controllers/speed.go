@@ -0,0 +1,41 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	t4sv1 "github.com/tkna/t4s/api/v1"
+)
+
+// Lowest wait time in millisec which the speed curve can set.
+const minWait = 200
+
+// currentWait returns the wait time at the current level following the speed curve.
+// The waits of the speed curve are clamped to minWait in case the Board was created without the validation.
+func currentWait(board *t4sv1.Board) int {
+	wait := board.Spec.Wait
+	from := 0
+	for _, s := range board.Spec.SpeedCurve {
+		if s.Level <= board.Status.Level && s.Level >= from {
+			wait = s.Wait
+			if wait < minWait {
+				wait = minWait
+			}
+			from = s.Level
+		}
+	}
+	return wait
+}
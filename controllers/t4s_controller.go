@@ -19,6 +19,7 @@ package controllers
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"os"
 
@@ -27,6 +28,7 @@ import (
 	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/api/equality"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -38,10 +40,17 @@ import (
 	rbacv1apply "k8s.io/client-go/applyconfigurations/rbac/v1"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	t4sv1 "github.com/tkna/t4s/api/v1"
 	"github.com/tkna/t4s/pkg/constants"
@@ -80,27 +89,157 @@ func (r *T4sReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 		return ctrl.Result{}, nil
 	}
 
-	if err := r.reconcileMino(ctx, t4s); err != nil {
-		return ctrl.Result{}, err
+	currentStatus := t4s.Status.DeepCopy()
+
+	err = r.reconcileMino(ctx, t4s)
+	setReconcileCondition(&t4s, t4sv1.T4sMinosReady, err)
+	if err == nil {
+		err = r.reconcileBoard(ctx, t4s)
+		if err != nil {
+			setReconcileCondition(&t4s, t4sv1.T4sBoardReady, err)
+		}
+	}
+	if err == nil {
+		err = r.reconcileApp(ctx, t4s)
+		if err != nil {
+			setReconcileCondition(&t4s, t4sv1.T4sAppAvailable, err)
+		}
 	}
 
-	if err := r.reconcileBoard(ctx, t4s); err != nil {
+	if statusErr := r.updateStatus(ctx, &t4s, currentStatus, err == nil); statusErr != nil {
+		return ctrl.Result{}, statusErr
+	}
+	if err != nil {
 		return ctrl.Result{}, err
 	}
 
-	if err := r.reconcileApp(ctx, t4s); err != nil {
+	logger.Info("reconcile T4s successfully")
+	return ctrl.Result{}, nil
+}
+
+// reconcileStatus updates only the status of T4s with the status of the Board, keeping the conditions.
+func (r *T4sReconciler) reconcileStatus(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	logger := log.FromContext(ctx)
+
+	var t4s t4sv1.T4s
+	err := r.Get(ctx, req.NamespacedName, &t4s)
+	if errors.IsNotFound(err) {
+		return ctrl.Result{}, nil
+	}
+	if err != nil {
+		logger.Error(err, "unable to get T4s")
 		return ctrl.Result{}, err
 	}
+	if !t4s.ObjectMeta.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
 
-	logger.Info("reconcile T4s successfully")
+	if err := r.updateStatus(ctx, &t4s, t4s.Status.DeepCopy(), false); err != nil {
+		return ctrl.Result{}, err
+	}
 	return ctrl.Result{}, nil
 }
 
+// setReconcileCondition sets the condition by the result of the reconciliation.
+func setReconcileCondition(t4s *t4sv1.T4s, conditionType string, err error) {
+	condition := metav1.Condition{
+		Type:               conditionType,
+		Status:             metav1.ConditionTrue,
+		ObservedGeneration: t4s.Generation,
+		Reason:             "Reconciled",
+	}
+	if err != nil {
+		condition.Status = metav1.ConditionFalse
+		condition.Reason = "ReconcileFailed"
+		condition.Message = err.Error()
+	}
+	meta.SetStatusCondition(&t4s.Status.Conditions, condition)
+}
+
+// updateStatus updates the status of T4s with the Board, the deployment and the service of the app.
+// If reconciled is false, the conditions set by the failed reconciliation are kept. It is not updated if nothing has changed from currentStatus.
+func (r *T4sReconciler) updateStatus(ctx context.Context, t4s *t4sv1.T4s, currentStatus *t4sv1.T4sStatus, reconciled bool) error {
+	logger := log.FromContext(ctx)
+
+	board := &t4sv1.Board{}
+	err := r.Get(ctx, client.ObjectKey{Namespace: t4s.Namespace, Name: t4s.Name}, board)
+	if err != nil && !errors.IsNotFound(err) {
+		logger.Error(err, "failed to get Board")
+		return err
+	}
+	if reconciled {
+		condition := metav1.Condition{
+			Type:               t4sv1.T4sBoardReady,
+			Status:             metav1.ConditionTrue,
+			ObservedGeneration: t4s.Generation,
+			Reason:             "BoardFound",
+		}
+		if errors.IsNotFound(err) || !board.DeletionTimestamp.IsZero() {
+			condition.Status = metav1.ConditionFalse
+			condition.Reason = "BoardNotFound"
+		}
+		meta.SetStatusCondition(&t4s.Status.Conditions, condition)
+	}
+	t4s.Status.BoardState = board.Status.State
+	t4s.Status.Score = board.Status.Score
+
+	dep := &appsv1.Deployment{}
+	err = r.Get(ctx, client.ObjectKey{Namespace: t4s.Namespace, Name: appName(*t4s)}, dep)
+	if err != nil && !errors.IsNotFound(err) {
+		logger.Error(err, "failed to get Deployment")
+		return err
+	}
+	if reconciled {
+		condition := metav1.Condition{
+			Type:               t4sv1.T4sAppAvailable,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: t4s.Generation,
+			Reason:             "DeploymentUnavailable",
+		}
+		for _, c := range dep.Status.Conditions {
+			if c.Type == appsv1.DeploymentAvailable && c.Status == corev1.ConditionTrue {
+				condition.Status = metav1.ConditionTrue
+				condition.Reason = "DeploymentAvailable"
+			}
+		}
+		meta.SetStatusCondition(&t4s.Status.Conditions, condition)
+	}
+
+	svc := &corev1.Service{}
+	err = r.Get(ctx, client.ObjectKey{Namespace: t4s.Namespace, Name: appName(*t4s)}, svc)
+	if err != nil && !errors.IsNotFound(err) {
+		logger.Error(err, "failed to get Service")
+		return err
+	}
+	t4s.Status.NodePort = 0
+	for _, port := range svc.Spec.Ports {
+		t4s.Status.NodePort = port.NodePort
+	}
+	t4s.Status.LoadBalancerAddress = ""
+	t4s.Status.URL = ""
+	for _, ingress := range svc.Status.LoadBalancer.Ingress {
+		t4s.Status.LoadBalancerAddress = ingress.IP
+		if ingress.IP == "" {
+			t4s.Status.LoadBalancerAddress = ingress.Hostname
+		}
+		t4s.Status.URL = fmt.Sprintf("http://%s:8000/", t4s.Status.LoadBalancerAddress)
+	}
+
+	if equality.Semantic.DeepEqual(&t4s.Status, currentStatus) {
+		return nil
+	}
+	if err := r.Status().Update(ctx, t4s); err != nil {
+		logger.Error(err, "failed to update T4s status")
+		return err
+	}
+	return nil
+}
+
 func (r *T4sReconciler) reconcileBoard(ctx context.Context, t4s t4sv1.T4s) error {
 	logger := log.FromContext(ctx)
 
 	board := &t4sv1.Board{}
-	err := r.Get(ctx, client.ObjectKey{Namespace: t4s.Namespace, Name: constants.BoardName}, board)
+	err := r.Get(ctx, client.ObjectKey{Namespace: t4s.Namespace, Name: t4s.Name}, board)
 	notFound := errors.IsNotFound(err)
 	if err != nil && !notFound {
 		logger.Error(err, "failed to get Board")
@@ -112,7 +251,22 @@ func (r *T4sReconciler) reconcileBoard(ctx context.Context, t4s t4sv1.T4s) error
 	}
 
 	needsRecreation := t4s.Spec.Width != board.Spec.Width || t4s.Spec.Height != board.Spec.Height
-	needsUpdate := t4s.Spec.Wait != board.Spec.Wait
+	needsUpdate := t4s.Spec.Wait != board.Spec.Wait ||
+		!equality.Semantic.DeepEqual(t4s.Spec.SpeedCurve, board.Spec.SpeedCurve) ||
+		t4s.Spec.Randomizer != board.Spec.Randomizer ||
+		t4s.Spec.NextCount != board.Spec.NextCount ||
+		t4s.Spec.LockDelay != board.Spec.LockDelay ||
+		t4s.Spec.MaxLockResets != board.Spec.MaxLockResets ||
+		t4s.Spec.Mode != board.Spec.Mode ||
+		t4s.Spec.GoalLines != board.Spec.GoalLines ||
+		t4s.Spec.TimeLimitSeconds != board.Spec.TimeLimitSeconds ||
+		t4s.Spec.PuzzleGoal != board.Spec.PuzzleGoal ||
+		!equality.Semantic.DeepEqual(t4s.Spec.InitialData, board.Spec.InitialData) ||
+		!equality.Semantic.DeepEqual(t4s.Spec.Sequence, board.Spec.Sequence) ||
+		t4s.Spec.IdleTimeoutSeconds != board.Spec.IdleTimeoutSeconds ||
+		t4s.Spec.IdleState != board.Spec.IdleState ||
+		!equality.Semantic.DeepEqual(t4s.Spec.Players, board.Spec.Players) ||
+		!equality.Semantic.DeepEqual(t4s.Spec.Opponents, board.Spec.Opponents)
 
 	if !notFound && needsRecreation {
 		if err := r.Delete(ctx, board); err != nil {
@@ -124,12 +278,27 @@ func (r *T4sReconciler) reconcileBoard(ctx context.Context, t4s t4sv1.T4s) error
 		board := &t4sv1.Board{
 			ObjectMeta: metav1.ObjectMeta{
 				Namespace: t4s.Namespace,
-				Name:      constants.BoardName,
+				Name:      t4s.Name,
 			},
 			Spec: t4sv1.BoardSpec{
-				Width:  t4s.Spec.Width,
-				Height: t4s.Spec.Height,
-				Wait:   t4s.Spec.Wait,
+				Width:              t4s.Spec.Width,
+				Height:             t4s.Spec.Height,
+				Wait:               t4s.Spec.Wait,
+				SpeedCurve:         t4s.Spec.SpeedCurve,
+				Randomizer:         t4s.Spec.Randomizer,
+				NextCount:          t4s.Spec.NextCount,
+				LockDelay:          t4s.Spec.LockDelay,
+				MaxLockResets:      t4s.Spec.MaxLockResets,
+				Mode:               t4s.Spec.Mode,
+				GoalLines:          t4s.Spec.GoalLines,
+				TimeLimitSeconds:   t4s.Spec.TimeLimitSeconds,
+				PuzzleGoal:         t4s.Spec.PuzzleGoal,
+				InitialData:        t4s.Spec.InitialData,
+				Sequence:           t4s.Spec.Sequence,
+				IdleTimeoutSeconds: t4s.Spec.IdleTimeoutSeconds,
+				IdleState:          t4s.Spec.IdleState,
+				Players:            t4s.Spec.Players,
+				Opponents:          t4s.Spec.Opponents,
 			},
 		}
 		if err := ctrl.SetControllerReference(&t4s, board, r.Scheme); err != nil {
@@ -142,6 +311,21 @@ func (r *T4sReconciler) reconcileBoard(ctx context.Context, t4s t4sv1.T4s) error
 		}
 	} else if needsUpdate {
 		board.Spec.Wait = t4s.Spec.Wait
+		board.Spec.SpeedCurve = t4s.Spec.SpeedCurve
+		board.Spec.Randomizer = t4s.Spec.Randomizer
+		board.Spec.NextCount = t4s.Spec.NextCount
+		board.Spec.LockDelay = t4s.Spec.LockDelay
+		board.Spec.MaxLockResets = t4s.Spec.MaxLockResets
+		board.Spec.Mode = t4s.Spec.Mode
+		board.Spec.GoalLines = t4s.Spec.GoalLines
+		board.Spec.TimeLimitSeconds = t4s.Spec.TimeLimitSeconds
+		board.Spec.PuzzleGoal = t4s.Spec.PuzzleGoal
+		board.Spec.InitialData = t4s.Spec.InitialData
+		board.Spec.Sequence = t4s.Spec.Sequence
+		board.Spec.IdleTimeoutSeconds = t4s.Spec.IdleTimeoutSeconds
+		board.Spec.IdleState = t4s.Spec.IdleState
+		board.Spec.Players = t4s.Spec.Players
+		board.Spec.Opponents = t4s.Spec.Opponents
 		if err := r.Update(ctx, board); err != nil {
 			logger.Error(err, "failed to update Board")
 			return err
@@ -159,11 +343,12 @@ func (r *T4sReconciler) reconcileApp(ctx context.Context, t4s t4sv1.T4s) error {
 		return err
 	}
 	label := map[string]string{
-		"tier": "app",
+		"tier":                 "app",
+		constants.T4sNameLabel: t4s.Name,
 	}
 
 	// ServiceAccount
-	saName := "t4s-app-sa"
+	saName := appName(t4s) + "-sa"
 	sa := corev1apply.ServiceAccount(saName, t4s.Namespace).
 		WithLabels(label).
 		WithOwnerReferences(owner)
@@ -173,7 +358,7 @@ func (r *T4sReconciler) reconcileApp(ctx context.Context, t4s t4sv1.T4s) error {
 	}
 
 	// Role
-	t4sRoleName := "t4s-viewer-role"
+	t4sRoleName := t4s.Name + "-t4s-viewer-role"
 	role := rbacv1apply.Role(t4sRoleName, t4s.Namespace).
 		WithLabels(label).
 		WithOwnerReferences(owner).
@@ -188,7 +373,7 @@ func (r *T4sReconciler) reconcileApp(ctx context.Context, t4s t4sv1.T4s) error {
 		return err
 	}
 
-	boardRoleName := "board-editor-role"
+	boardRoleName := t4s.Name + "-board-editor-role"
 	role = rbacv1apply.Role(boardRoleName, t4s.Namespace).
 		WithLabels(label).
 		WithOwnerReferences(owner).
@@ -207,7 +392,7 @@ func (r *T4sReconciler) reconcileApp(ctx context.Context, t4s t4sv1.T4s) error {
 		return err
 	}
 
-	actionRoleName := "action-editor-role"
+	actionRoleName := t4s.Name + "-action-editor-role"
 	role = rbacv1apply.Role(actionRoleName, t4s.Namespace).
 		WithLabels(label).
 		WithOwnerReferences(owner).
@@ -222,7 +407,7 @@ func (r *T4sReconciler) reconcileApp(ctx context.Context, t4s t4sv1.T4s) error {
 		return err
 	}
 
-	minoRoleName := "mino-viewer-role"
+	minoRoleName := t4s.Name + "-mino-viewer-role"
 	role = rbacv1apply.Role(minoRoleName, t4s.Namespace).
 		WithLabels(label).
 		WithOwnerReferences(owner).
@@ -238,7 +423,7 @@ func (r *T4sReconciler) reconcileApp(ctx context.Context, t4s t4sv1.T4s) error {
 	}
 
 	// RoleBinding
-	rbName := "t4s-viewer-rb"
+	rbName := t4s.Name + "-t4s-viewer-rb"
 	rb := rbacv1apply.RoleBinding(rbName, t4s.Namespace).
 		WithLabels(label).
 		WithOwnerReferences(owner).
@@ -255,7 +440,7 @@ func (r *T4sReconciler) reconcileApp(ctx context.Context, t4s t4sv1.T4s) error {
 		return err
 	}
 
-	rbName = "board-editor-rb"
+	rbName = t4s.Name + "-board-editor-rb"
 	rb = rbacv1apply.RoleBinding(rbName, t4s.Namespace).
 		WithLabels(label).
 		WithOwnerReferences(owner).
@@ -272,7 +457,7 @@ func (r *T4sReconciler) reconcileApp(ctx context.Context, t4s t4sv1.T4s) error {
 		return err
 	}
 
-	rbName = "action-editor-rb"
+	rbName = t4s.Name + "-action-editor-rb"
 	rb = rbacv1apply.RoleBinding(rbName, t4s.Namespace).
 		WithLabels(label).
 		WithOwnerReferences(owner).
@@ -289,7 +474,7 @@ func (r *T4sReconciler) reconcileApp(ctx context.Context, t4s t4sv1.T4s) error {
 		return err
 	}
 
-	rbName = "mino-viewer-rb"
+	rbName = t4s.Name + "-mino-viewer-rb"
 	rb = rbacv1apply.RoleBinding(rbName, t4s.Namespace).
 		WithLabels(label).
 		WithOwnerReferences(owner).
@@ -307,7 +492,7 @@ func (r *T4sReconciler) reconcileApp(ctx context.Context, t4s t4sv1.T4s) error {
 	}
 
 	// Deployment
-	depName := "t4s-app"
+	depName := appName(t4s)
 	dep := appsv1apply.Deployment(depName, t4s.Namespace).
 		WithLabels(label).
 		WithOwnerReferences(owner).
@@ -319,7 +504,7 @@ func (r *T4sReconciler) reconcileApp(ctx context.Context, t4s t4sv1.T4s) error {
 				WithSpec(corev1apply.PodSpec().
 					WithServiceAccountName(saName).
 					WithContainers(corev1apply.Container().
-						WithName(constants.BoardName).
+						WithName("app").
 						WithImage(constants.AppImage).
 						WithImagePullPolicy(corev1.PullIfNotPresent).
 						WithPorts(corev1apply.ContainerPort().
@@ -337,7 +522,7 @@ func (r *T4sReconciler) reconcileApp(ctx context.Context, t4s t4sv1.T4s) error {
 						).
 						WithEnv(corev1apply.EnvVar().
 							WithName("BOARD_NAME").
-							WithValue(constants.BoardName),
+							WithValue(t4s.Name),
 						),
 					),
 				),
@@ -349,7 +534,7 @@ func (r *T4sReconciler) reconcileApp(ctx context.Context, t4s t4sv1.T4s) error {
 	}
 
 	// Service
-	svcName := "t4s-app"
+	svcName := appName(t4s)
 	svcType := corev1.ServiceTypeNodePort
 	if t4s.Spec.ServiceType == "LoadBalancer" {
 		svcType = corev1.ServiceTypeLoadBalancer
@@ -601,6 +786,10 @@ func (r *T4sReconciler) reconcileMino(ctx context.Context, t4s t4sv1.T4s) error
 			break
 		}
 		logger.Info("Reading mino from yaml file", "mino.GetName()", mino.GetName())
+		// Default it as the CRD does, otherwise the Mino is updated in every reconciliation
+		if mino.Spec.KickTable == "" {
+			mino.Spec.KickTable = t4sv1.StandardKickTable
+		}
 
 		m := &t4sv1.Mino{}
 		m.SetNamespace(t4s.Namespace)
@@ -610,7 +799,11 @@ func (r *T4sReconciler) reconcileMino(ctx context.Context, t4s t4sv1.T4s) error
 			m.Spec.MinoID = mino.Spec.MinoID
 			m.Spec.Coords = mino.Spec.Coords
 			m.Spec.Color = mino.Spec.Color
-			return ctrl.SetControllerReference(&t4s, m, r.Scheme)
+			m.Spec.KickTable = mino.Spec.KickTable
+			m.Spec.Kicks = mino.Spec.Kicks
+			m.Spec.Rotations = mino.Spec.Rotations
+			// Minoes are shared by all the T4s in the namespace
+			return controllerutil.SetOwnerReference(&t4s, m, r.Scheme)
 		})
 		if err != nil {
 			logger.Error(err, "unable to create or update Mino")
@@ -629,6 +822,11 @@ func (r *T4sReconciler) reconcileMino(ctx context.Context, t4s t4sv1.T4s) error
 	return nil
 }
 
+// appName returns the name of the deployment and the service of the app for the T4s.
+func appName(t4s t4sv1.T4s) string {
+	return t4s.Name + "-app"
+}
+
 func ownerRef(t4s t4sv1.T4s, scheme *runtime.Scheme) (*metav1apply.OwnerReferenceApplyConfiguration, error) {
 	gvk, err := apiutil.GVKForObject(&t4s, scheme)
 	if err != nil {
@@ -645,13 +843,35 @@ func ownerRef(t4s t4sv1.T4s, scheme *runtime.Scheme) (*metav1apply.OwnerReferenc
 }
 
 // SetupWithManager sets up the controller with the Manager.
+// The changes of the status of Board are handled by another controller which only updates the status of T4s,
+// so that the Board being played does not apply the other resources again and again.
 func (r *T4sReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	err := ctrl.NewControllerManagedBy(mgr).
 		For(&t4sv1.T4s{}).
 		Owns(&appsv1.Deployment{}).
 		Owns(&corev1.Service{}).
 		Owns(&corev1.ServiceAccount{}).
 		Owns(&rbacv1.Role{}).
 		Owns(&rbacv1.RoleBinding{}).
+		Owns(&t4sv1.Board{}, builder.WithPredicates(predicate.GenerationChangedPredicate{})).
 		Complete(r)
+	if err != nil {
+		return err
+	}
+
+	c, err := controller.New("t4s-status", mgr, controller.Options{Reconciler: reconcile.Func(r.reconcileStatus)})
+	if err != nil {
+		return err
+	}
+	return c.Watch(&source.Kind{Type: &t4sv1.Board{}}, &handler.EnqueueRequestForOwner{OwnerType: &t4sv1.T4s{}, IsController: true},
+		// only the changes of the status shown in T4s
+		predicate.Funcs{
+			CreateFunc: func(event.CreateEvent) bool { return false },
+			DeleteFunc: func(event.DeleteEvent) bool { return false },
+			UpdateFunc: func(e event.UpdateEvent) bool {
+				oldBoard := e.ObjectOld.(*t4sv1.Board)
+				newBoard := e.ObjectNew.(*t4sv1.Board)
+				return oldBoard.Status.State != newBoard.Status.State || oldBoard.Status.Score != newBoard.Status.Score
+			},
+		})
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/tkna/t4s/pkg/constants"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -86,7 +87,7 @@ var _ = Describe("T4s controller", func() {
 			By("checking Board will be created")
 			board := &t4sv1.Board{}
 			Eventually(func() error {
-				return k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: constants.BoardName}, board)
+				return k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: "test"}, board)
 			}).Should(Succeed())
 			Expect(board.Spec).To(MatchFields(IgnoreExtras, Fields{
 				"Width":  Equal(t4s.Spec.Width),
@@ -98,14 +99,15 @@ var _ = Describe("T4s controller", func() {
 			By("checking the deployment app will be created")
 			dep := &appsv1.Deployment{}
 			Eventually(func() error {
-				return k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: "t4s-app"}, dep)
+				return k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: "test-app"}, dep)
 			}).Should(Succeed())
 			Expect(dep.Labels).To(MatchAllKeys(Keys{
-				"tier": Equal("app"),
+				"tier":                 Equal("app"),
+				constants.T4sNameLabel: Equal("test"),
 			}))
 			Expect(dep.Spec.Template.Spec.Containers).To(HaveLen(1))
 			Expect(dep.Spec.Template.Spec.Containers[0]).To(MatchFields(IgnoreExtras, Fields{
-				"Name": Equal(constants.BoardName),
+				"Name": Equal("app"),
 				"Env": ConsistOf([]corev1.EnvVar{
 					{
 						Name:  "NAMESPACE",
@@ -117,7 +119,7 @@ var _ = Describe("T4s controller", func() {
 					},
 					{
 						Name:  "BOARD_NAME",
-						Value: constants.BoardName,
+						Value: "test",
 					},
 				}),
 			}))
@@ -125,10 +127,11 @@ var _ = Describe("T4s controller", func() {
 			By("checking a service for app will be created")
 			svc := &corev1.Service{}
 			Eventually(func() error {
-				return k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: "t4s-app"}, svc)
+				return k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: "test-app"}, svc)
 			}).Should(Succeed())
 			Expect(svc.Labels).To(MatchAllKeys(Keys{
-				"tier": Equal("app"),
+				"tier":                 Equal("app"),
+				constants.T4sNameLabel: Equal("test"),
 			}))
 			Expect(svc.Spec).To(MatchFields(IgnoreExtras, Fields{
 				"Type": Equal(corev1.ServiceTypeNodePort),
@@ -152,6 +155,17 @@ var _ = Describe("T4s controller", func() {
 			Expect(minoes.Items[0].Spec).To(MatchFields(IgnoreExtras, Fields{
 				"MinoID": Equal(1),
 			}))
+
+			By("checking the status of T4s will be updated")
+			Eventually(func(g Gomega) {
+				g.Expect(k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: "test"}, t4s)).To(Succeed())
+				g.Expect(meta.IsStatusConditionTrue(t4s.Status.Conditions, t4sv1.T4sMinosReady)).To(BeTrue())
+				g.Expect(meta.IsStatusConditionTrue(t4s.Status.Conditions, t4sv1.T4sBoardReady)).To(BeTrue())
+				// no pods are running in envtest
+				g.Expect(meta.IsStatusConditionFalse(t4s.Status.Conditions, t4sv1.T4sAppAvailable)).To(BeTrue())
+				g.Expect(t4s.Status.NodePort).To(Equal(int32(30080)))
+				g.Expect(t4s.Status.BoardState).To(Equal(t4sv1.GameOver))
+			}).Should(Succeed())
 		})
 	})
 
@@ -188,10 +202,11 @@ var _ = Describe("T4s controller", func() {
 			By("checking a service for app will be created")
 			svc := &corev1.Service{}
 			Eventually(func() error {
-				return k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: "t4s-app"}, svc)
+				return k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: "test-app"}, svc)
 			}).Should(Succeed())
 			Expect(svc.Labels).To(MatchAllKeys(Keys{
-				"tier": Equal("app"),
+				"tier":                 Equal("app"),
+				constants.T4sNameLabel: Equal("test"),
 			}))
 			Expect(svc.Spec).To(MatchFields(IgnoreExtras, Fields{
 				"Type":                     Equal(corev1.ServiceTypeLoadBalancer),
@@ -229,7 +244,7 @@ var _ = Describe("T4s controller", func() {
 		By("checking Board will be created")
 		board := &t4sv1.Board{}
 		Eventually(func() error {
-			return k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: constants.BoardName}, board)
+			return k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: "test"}, board)
 		}).Should(Succeed())
 		Expect(board.Spec).To(MatchFields(IgnoreExtras, Fields{
 			"Width":  Equal(10),
@@ -244,7 +259,7 @@ var _ = Describe("T4s controller", func() {
 		Expect(err).ShouldNot(HaveOccurred())
 
 		Eventually(func() error {
-			if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: constants.BoardName}, board); err != nil {
+			if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: "test"}, board); err != nil {
 				return err
 			}
 			if board.Spec.Width != 10 || board.Spec.Height != 20 || board.Spec.Wait != 500 {
@@ -260,7 +275,7 @@ var _ = Describe("T4s controller", func() {
 		Expect(err).ShouldNot(HaveOccurred())
 
 		Eventually(func() error {
-			if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: constants.BoardName}, board); err != nil {
+			if err := k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: "test"}, board); err != nil {
 				return err
 			}
 			if board.Spec.Width != 15 || board.Spec.Height != 10 || board.Spec.Wait != 500 {
@@ -269,4 +284,68 @@ var _ = Describe("T4s controller", func() {
 			return nil
 		}).Should(Succeed())
 	})
+
+	It("should create resources for each T4s in a namespace", func() {
+		By("creating a namespace and 2 T4s")
+		nsName := "test-ns-multi"
+		ns := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: nsName,
+			},
+		}
+		err := k8sClient.Create(ctx, ns)
+		Expect(err).NotTo(HaveOccurred())
+
+		for _, name := range []string{"game-1", "game-2"} {
+			t4s := &t4sv1.T4s{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: nsName,
+					Name:      name,
+				},
+				Spec: t4sv1.T4sSpec{
+					Width:  10,
+					Height: 20,
+					Wait:   1000,
+				},
+			}
+			err = k8sClient.Create(ctx, t4s)
+			Expect(err).ShouldNot(HaveOccurred())
+		}
+
+		By("checking the Board and the app will be created for each T4s")
+		for _, name := range []string{"game-1", "game-2"} {
+			board := &t4sv1.Board{}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: name}, board)
+			}).Should(Succeed())
+			Expect(metav1.GetControllerOf(board).Name).To(Equal(name))
+
+			dep := &appsv1.Deployment{}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: name + "-app"}, dep)
+			}).Should(Succeed())
+			Expect(dep.Spec.Selector.MatchLabels).To(HaveKeyWithValue(constants.T4sNameLabel, name))
+
+			svc := &corev1.Service{}
+			Eventually(func() error {
+				return k8sClient.Get(ctx, client.ObjectKey{Namespace: nsName, Name: name + "-app"}, svc)
+			}).Should(Succeed())
+			Expect(svc.Spec.Selector).To(HaveKeyWithValue(constants.T4sNameLabel, name))
+		}
+
+		By("checking Minoes will be shared by the T4s")
+		minoes := &t4sv1.MinoList{}
+		Eventually(func() error {
+			if err := k8sClient.List(ctx, minoes, &client.ListOptions{Namespace: nsName}); err != nil {
+				return err
+			}
+			if len(minoes.Items) != 7 {
+				return errors.New("the number of Minoes is not 7")
+			}
+			if len(minoes.Items[0].OwnerReferences) != 2 {
+				return errors.New("Mino is not owned by both T4s")
+			}
+			return nil
+		}).Should(Succeed())
+	})
 })
@@ -0,0 +1,108 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	t4sv1 "github.com/tkna/t4s/api/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// Interval to check the opponents after the Board has topped out, until the winner is decided.
+const versusPollInterval = time.Second
+
+// reconcileVersus takes the garbage rows sent by the opponents, and decides the winner when all but one player has topped out.
+// It returns the time to check the opponents again while the Board is waiting for the result or for an opponent which is not found.
+func (r *BoardReconciler) reconcileVersus(ctx context.Context, board *t4sv1.Board) (time.Duration, error) {
+	logger := log.FromContext(ctx)
+
+	if len(board.Spec.Opponents) == 0 || board.Status.Winner != "" {
+		return 0, nil
+	}
+	if board.Status.GarbageReceived == nil {
+		board.Status.GarbageReceived = make(map[string]int)
+	}
+
+	var alive []string
+	toppedOut := 0
+	undecided := false
+	if board.Status.State == t4sv1.GameOver {
+		toppedOut++
+	} else {
+		alive = append(alive, board.Name)
+	}
+	for _, name := range board.Spec.Opponents {
+		var opponent t4sv1.Board
+		err := r.Get(ctx, client.ObjectKey{Namespace: board.Namespace, Name: name}, &opponent)
+		if errors.IsNotFound(err) {
+			// The opponent may be starting a new game, so the winner is not decided until it is found again
+			logger.Info("opponent not found", "opponent", name)
+			undecided = true
+			continue
+		}
+		if err != nil {
+			logger.Error(err, "unable to get opponent", "opponent", name)
+			return 0, err
+		}
+
+		receiveGarbage(board, &opponent)
+		if opponent.Status.Winner != "" {
+			// The opponent has already seen the end of the game
+			if board.Status.State == t4sv1.GameOver {
+				board.Status.Winner = opponent.Status.Winner
+				return 0, nil
+			}
+			continue
+		}
+		if opponent.Status.State == t4sv1.GameOver {
+			toppedOut++
+		} else {
+			alive = append(alive, name)
+		}
+	}
+
+	if undecided {
+		return versusPollInterval, nil
+	}
+	if len(alive) == 1 && toppedOut > 0 {
+		logger.Info("versus game is over", "winner", alive[0])
+		board.Status.Winner = alive[0]
+		if alive[0] == board.Name {
+			clearGame(board, t4sv1.ReasonWon)
+		}
+		return 0, nil
+	}
+	if board.Status.State == t4sv1.GameOver && len(alive) > 1 {
+		return versusPollInterval, nil
+	}
+	return 0, nil
+}
+
+// receiveGarbage adds the garbage rows sent by the opponent since the last check to board.Status.PendingGarbage.
+func receiveGarbage(board *t4sv1.Board, opponent *t4sv1.Board) {
+	sent := opponent.Status.GarbageSent
+	received, ok := board.Status.GarbageReceived[opponent.Name]
+	// The rows sent before the Board is created or before the opponent starts a new game are not received
+	if ok && sent > received {
+		board.Status.PendingGarbage += sent - received
+	}
+	board.Status.GarbageReceived[opponent.Name] = sent
+}
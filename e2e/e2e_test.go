@@ -31,18 +31,18 @@ var _ = Describe("t4s", func() {
 		}).Should(Succeed())
 
 		Eventually(func() error {
-			out, err := kubectl(nil, "get", "-n", namespace, "deployment", "t4s-app")
+			out, err := kubectl(nil, "get", "-n", namespace, "deployment", "t4s-1-app")
 			if err != nil {
 				return err
 			}
 			if !bytes.Contains(out, []byte("1/1")) {
-				return fmt.Errorf("Deployment t4s-app is not ready")
+				return fmt.Errorf("Deployment t4s-1-app is not ready")
 			}
 			return nil
 		}).Should(Succeed())
 
 		Eventually(func() error {
-			_, err := kubectl(nil, "get", "-n", namespace, "service", "t4s-app")
+			_, err := kubectl(nil, "get", "-n", namespace, "service", "t4s-1-app")
 			return err
 		}).Should(Succeed())
 	})
@@ -57,12 +57,12 @@ var _ = Describe("t4s", func() {
 		kubectlSafe(t4sYAML, "apply", "-n", namespace, "-f", "-")
 
 		Eventually(func() error {
-			_, err := kubectl(nil, "get", "-n", namespace, "deployment", "t4s-app")
+			_, err := kubectl(nil, "get", "-n", namespace, "deployment", "t4s-1-app")
 			return err
 		}).Should(Succeed())
 
 		Eventually(func() error {
-			_, err := kubectl(nil, "get", "-n", namespace, "service", "t4s-app")
+			_, err := kubectl(nil, "get", "-n", namespace, "service", "t4s-1-app")
 			return err
 		}).Should(Succeed())
 
@@ -70,12 +70,12 @@ var _ = Describe("t4s", func() {
 		kubectlSafe(nil, "delete", "-n", namespace, "t4s", "t4s-1")
 
 		Eventually(func() error {
-			_, err := kubectl(nil, "get", "-n", namespace, "deployment", "t4s-app")
+			_, err := kubectl(nil, "get", "-n", namespace, "deployment", "t4s-1-app")
 			return err
 		}).ShouldNot(Succeed())
 
 		Eventually(func() error {
-			_, err := kubectl(nil, "get", "-n", namespace, "service", "t4s-app")
+			_, err := kubectl(nil, "get", "-n", namespace, "service", "t4s-1-app")
 			return err
 		}).ShouldNot(Succeed())
 	})
@@ -95,18 +95,18 @@ var _ = Describe("t4s", func() {
 		}).Should(Succeed())
 
 		Eventually(func() error {
-			out, err := kubectl(nil, "get", "-n", namespace, "deployment", "t4s-app")
+			out, err := kubectl(nil, "get", "-n", namespace, "deployment", "t4s-1-app")
 			if err != nil {
 				return err
 			}
 			if !bytes.Contains(out, []byte("1/1")) {
-				return fmt.Errorf("Deployment t4s-app is not ready")
+				return fmt.Errorf("Deployment t4s-1-app is not ready")
 			}
 			return nil
 		}).Should(Succeed())
 
 		Eventually(func() error {
-			_, err := kubectl(nil, "get", "-n", namespace, "service", "t4s-app")
+			_, err := kubectl(nil, "get", "-n", namespace, "service", "t4s-1-app")
 			return err
 		}).Should(Succeed())
 
@@ -122,81 +122,81 @@ var _ = Describe("t4s", func() {
 			return err
 		}).Should(Succeed())
 
-		By("sending http POST request to http://t4s-app:8000/board")
+		By("sending http POST request to http://t4s-1-app:8000/board")
 		Eventually(func() error {
-			resp, err := kubectl(nil, "exec", "-n", namespace, "alpine", "--", "curl", "-i", "-X", "POST", "t4s-app:8000/board")
+			resp, err := kubectl(nil, "exec", "-n", namespace, "alpine", "--", "curl", "-i", "-X", "POST", "t4s-1-app:8000/board")
 			if err != nil {
 				return err
 			}
 			if !bytes.Contains(resp, []byte("200 OK")) {
-				return fmt.Errorf("failed to post http://t4s-app:8000/board")
+				return fmt.Errorf("failed to post http://t4s-1-app:8000/board")
 			}
 			return nil
 		}).Should(Succeed())
 
 		By("checking the board")
 		Eventually(func() error {
-			_, err := kubectl(nil, "get", "-n", namespace, "board", "board")
+			_, err := kubectl(nil, "get", "-n", namespace, "board", "t4s-1")
 			return err
 		}).Should(Succeed())
 
-		By("sending http GET request to http://t4s-app:8000/")
+		By("sending http GET request to http://t4s-1-app:8000/")
 		Eventually(func() error {
-			resp, err := kubectl(nil, "exec", "-n", namespace, "alpine", "--", "curl", "-i", "t4s-app:8000")
+			resp, err := kubectl(nil, "exec", "-n", namespace, "alpine", "--", "curl", "-i", "t4s-1-app:8000")
 			if err != nil {
 				return err
 			}
 			if !bytes.Contains(resp, []byte("200 OK")) {
-				return fmt.Errorf("failed to get http://t4s-app:8000/")
+				return fmt.Errorf("failed to get http://t4s-1-app:8000/")
 			}
 			return nil
 		}).Should(Succeed())
 
-		By("sending http GET request to http://t4s-app:8000/board")
+		By("sending http GET request to http://t4s-1-app:8000/board")
 		Eventually(func() error {
-			resp, err := kubectl(nil, "exec", "-n", namespace, "alpine", "--", "curl", "-i", "t4s-app:8000/board")
+			resp, err := kubectl(nil, "exec", "-n", namespace, "alpine", "--", "curl", "-i", "t4s-1-app:8000/board")
 			if err != nil {
 				return err
 			}
 			if !bytes.Contains(resp, []byte("200 OK")) {
-				return fmt.Errorf("failed to get http://t4s-app:8000/board")
+				return fmt.Errorf("failed to get http://t4s-1-app:8000/board")
 			}
 			return nil
 		}).Should(Succeed())
 
-		By("sending http GET request to http://t4s-app:8000/colors")
+		By("sending http GET request to http://t4s-1-app:8000/colors")
 		Eventually(func() error {
-			resp, err := kubectl(nil, "exec", "-n", namespace, "alpine", "--", "curl", "-i", "t4s-app:8000/colors")
+			resp, err := kubectl(nil, "exec", "-n", namespace, "alpine", "--", "curl", "-i", "t4s-1-app:8000/colors")
 			if err != nil {
 				return err
 			}
 			if !bytes.Contains(resp, []byte("200 OK")) {
-				return fmt.Errorf("failed to get http://t4s-app:8000/colors")
+				return fmt.Errorf("failed to get http://t4s-1-app:8000/colors")
 			}
 			return nil
 		}).Should(Succeed())
 
-		By("sending http GET request to http://t4s-app:8000/wait")
+		By("sending http GET request to http://t4s-1-app:8000/wait")
 		Eventually(func() error {
-			resp, err := kubectl(nil, "exec", "-n", namespace, "alpine", "--", "curl", "-i", "t4s-app:8000/wait")
+			resp, err := kubectl(nil, "exec", "-n", namespace, "alpine", "--", "curl", "-i", "t4s-1-app:8000/wait")
 			if err != nil {
 				return err
 			}
 			if !bytes.Contains(resp, []byte("200 OK")) {
-				return fmt.Errorf("failed to get http://t4s-app:8000/wait")
+				return fmt.Errorf("failed to get http://t4s-1-app:8000/wait")
 			}
 			return nil
 		}).Should(Succeed())
 
-		By("sending http POST request to http://t4s-app:8000/actions")
+		By("sending http POST request to http://t4s-1-app:8000/actions")
 		Eventually(func() error {
 			resp, err := kubectl(nil, "exec", "-n", namespace, "alpine", "--", "curl", "-i", "-X", "POST", "-H", `Content-Type:application/json`,
-				"-d", `{"op": "left"}`, "t4s-app:8000/actions")
+				"-d", `{"op": "left"}`, "t4s-1-app:8000/actions")
 			if err != nil {
 				return err
 			}
 			if !bytes.Contains(resp, []byte("200 OK")) {
-				return fmt.Errorf("failed to post http://t4s-app:8000/actions")
+				return fmt.Errorf("failed to post http://t4s-1-app:8000/actions")
 			}
 			return nil
 		}).Should(Succeed())
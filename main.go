@@ -19,6 +19,7 @@ package main
 import (
 	"flag"
 	"os"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
@@ -53,11 +54,17 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var maxActionsPerReconcile int
+	var actionTTL time.Duration
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.IntVar(&maxActionsPerReconcile, "max-actions-per-reconcile", controllers.DefaultMaxActionsPerReconcile,
+		"The maximum number of Actions applied to a Board in a reconciliation. The rest are applied in the next one.")
+	flag.DurationVar(&actionTTL, "action-ttl", time.Minute,
+		"How long the processed Actions are kept to observe their results. 0 deletes them as soon as they are processed.")
 	opts := zap.Options{
 		Development: true,
 	}
@@ -86,8 +93,10 @@ func main() {
 		os.Exit(1)
 	}
 	if err = (&controllers.BoardReconciler{
-		Client: boardClient,
-		Scheme: mgr.GetScheme(),
+		Client:                 boardClient,
+		Scheme:                 mgr.GetScheme(),
+		MaxActionsPerReconcile: maxActionsPerReconcile,
+		ActionTTL:              actionTTL,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Board")
 		os.Exit(1)
@@ -110,6 +119,18 @@ func main() {
 		setupLog.Error(err, "unable to create webhook", "webhook", "T4s")
 		os.Exit(1)
 	}
+	if err = t4sv1.SetupActionWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "Action")
+		os.Exit(1)
+	}
+	if err = t4sv1.SetupMinoWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "Mino")
+		os.Exit(1)
+	}
+	if err = t4sv1.SetupBoardWebhookWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create webhook", "webhook", "Board")
+		os.Exit(1)
+	}
 	//+kubebuilder:scaffold:builder
 
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
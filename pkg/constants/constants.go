@@ -8,8 +8,20 @@ const (
 	// Default path to the built-in mino yaml.
 	DefaultMinoConf = "conf/minoes.yaml"
 
-	// Name of the board.
+	// Default name of the board, used by t4s-app when BOARD_NAME is not given.
 	BoardName = "board"
+
+	// Label key to record the Board which an Action is applied to.
+	ActionBoardLabel = "t4s.tkna.net/board"
+
+	// Label key to record the T4s which the app resources belong to.
+	T4sNameLabel = "t4s.tkna.net/t4s"
+
+	// Label key to record which component created an Action.
+	ActionSourceLabel = "t4s.tkna.net/source"
+
+	// Value of ActionSourceLabel for the Actions created by Cron.
+	ActionSourceCron = "cron"
 )
 
 var (
@@ -0,0 +1,146 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package engine implements the rules of the game on a plain board: collisions, moves, rotations with wall kicks,
+// line clears, T-spins and garbage rows, and the state machine of a game on top of them in Game.
+// It does not depend on Kubernetes, so that the rules can be tested on their own.
+package engine
+
+import (
+	"sort"
+)
+
+// Coord is a coordinate on the board.
+// Absolute coordinates count "y" downward from the top row, while the coordinates relative to the center of a piece count "y" upward.
+type Coord struct {
+	X int
+	Y int
+}
+
+// Board is a grid of cells from the top row to the bottom. 0 is an empty cell.
+type Board struct {
+	Width  int
+	Height int
+	Cells  [][]int
+}
+
+// NewBoard returns an empty board.
+func NewBoard(width, height int) Board {
+	cells := make([][]int, height)
+	for y := range cells {
+		cells[y] = make([]int, width)
+	}
+	return Board{Width: width, Height: height, Cells: cells}
+}
+
+// Blocked reports whether a piece cannot occupy the cells.
+type Blocked func(cells []Coord) bool
+
+// Collides returns true if some of the cells are out of the board or already occupied.
+// Walls and the floor count as occupied, while the space above the top row does not exist.
+func (b Board) Collides(cells []Coord) bool {
+	for _, c := range cells {
+		if c.X < 0 || c.X >= b.Width || c.Y < 0 || c.Y >= b.Height {
+			return true
+		}
+		if b.Cells[c.Y][c.X] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Place fills the cells with the value.
+func (b Board) Place(cells []Coord, value int) {
+	for _, c := range cells {
+		b.Cells[c.Y][c.X] = value
+	}
+}
+
+// ClearRows removes the completed rows among the ones which the cells are in, and drops the rows above them.
+// It returns the removed rows in ascending order, or nil if no rows are completed.
+func (b Board) ClearRows(cells []Coord) []int {
+	completed := make(map[int]bool)
+	for _, c := range cells {
+		if _, checked := completed[c.Y]; checked {
+			continue
+		}
+		full := true
+		for _, cell := range b.Cells[c.Y] {
+			if cell == 0 {
+				full = false
+				break
+			}
+		}
+		completed[c.Y] = full
+	}
+
+	var rows []int
+	for y, full := range completed {
+		if full {
+			rows = append(rows, y)
+		}
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	sort.Ints(rows)
+
+	// Drop rows except the ones to be removed
+	newY := b.Height - 1
+	for y := b.Height - 1; y >= 0; y-- {
+		if completed[y] {
+			continue
+		}
+		copy(b.Cells[newY], b.Cells[y])
+		newY--
+	}
+	// Empty the rows left at the top
+	for ; newY >= 0; newY-- {
+		for x := range b.Cells[newY] {
+			b.Cells[newY][x] = 0
+		}
+	}
+	return rows
+}
+
+// PushGarbage pushes the rows up and fills the bottom ones with the value, leaving a hole in the column `hole`.
+// The number of rows is limited to the height of the board.
+// It returns false if some blocks are pushed out over the top.
+func (b Board) PushGarbage(rows, hole, value int) bool {
+	if rows > b.Height {
+		rows = b.Height
+	}
+
+	ok := true
+	for y := 0; y < rows; y++ {
+		for _, cell := range b.Cells[y] {
+			if cell != 0 {
+				ok = false
+			}
+		}
+	}
+	for y := 0; y < b.Height-rows; y++ {
+		copy(b.Cells[y], b.Cells[y+rows])
+	}
+	for y := b.Height - rows; y < b.Height; y++ {
+		for x := range b.Cells[y] {
+			b.Cells[y][x] = value
+		}
+		b.Cells[y][hole] = 0
+	}
+	return ok
+}
@@ -0,0 +1,369 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+)
+
+// T mino in the spawn state, pointing up
+var tShape = []Coord{{X: -1, Y: 0}, {X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 0}}
+
+var tSpec = Spec{Coords: tShape, KickTable: StandardKickTable}
+
+func boardOf(cells [][]int) Board {
+	return Board{Width: len(cells[0]), Height: len(cells), Cells: cells}
+}
+
+func TestCollides(t *testing.T) {
+	b := boardOf([][]int{
+		{0, 0, 0},
+		{0, 1, 0},
+		{0, 0, 0},
+	})
+	tests := []struct {
+		name  string
+		cells []Coord
+		want  bool
+	}{
+		{"empty cells", []Coord{{X: 0, Y: 0}, {X: 2, Y: 2}}, false},
+		{"occupied cell", []Coord{{X: 0, Y: 0}, {X: 1, Y: 1}}, true},
+		{"left wall", []Coord{{X: -1, Y: 0}}, true},
+		{"right wall", []Coord{{X: 3, Y: 0}}, true},
+		{"floor", []Coord{{X: 0, Y: 3}}, true},
+		{"above the top", []Coord{{X: 0, Y: -1}}, true},
+		{"no cells", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := b.Collides(tt.cells); got != tt.want {
+				t.Errorf("Collides(%v) = %v, want %v", tt.cells, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMove(t *testing.T) {
+	b := boardOf([][]int{
+		{0, 0, 0, 0},
+		{0, 0, 0, 0},
+		{0, 0, 0, 1},
+		{0, 0, 0, 0},
+	})
+	p := Piece{Center: Coord{X: 1, Y: 2}, Shape: tShape, Rotated: true}
+	tests := []struct {
+		name   string
+		dx, dy int
+		want   Coord
+		ok     bool
+	}{
+		{"left", -1, 0, Coord{X: 1, Y: 2}, false},
+		{"right", 1, 0, Coord{X: 1, Y: 2}, false},
+		{"down", 0, 1, Coord{X: 1, Y: 3}, true},
+		{"up", 0, -1, Coord{X: 1, Y: 1}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := Move(p, tt.dx, tt.dy, b.Collides)
+			if ok != tt.ok || got.Center != tt.want {
+				t.Errorf("Move(%d, %d) = %v, %v, want %v, %v", tt.dx, tt.dy, got.Center, ok, tt.want, tt.ok)
+			}
+			// Only a successful move cancels the rotation
+			if got.Rotated == ok {
+				t.Errorf("Move(%d, %d).Rotated = %v", tt.dx, tt.dy, got.Rotated)
+			}
+		})
+	}
+}
+
+func TestDrop(t *testing.T) {
+	b := boardOf([][]int{
+		{0, 0, 0, 0},
+		{0, 0, 0, 0},
+		{0, 0, 0, 0},
+		{0, 0, 0, 0},
+		{1, 0, 0, 0},
+	})
+	tests := []struct {
+		name   string
+		center Coord
+		want   Coord
+	}{
+		{"onto a block", Coord{X: 1, Y: 1}, Coord{X: 1, Y: 3}},
+		{"onto the floor", Coord{X: 2, Y: 1}, Coord{X: 2, Y: 4}},
+		{"already on the floor", Coord{X: 2, Y: 4}, Coord{X: 2, Y: 4}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Drop(Piece{Center: tt.center, Shape: tShape}, b.Collides)
+			if got.Center != tt.want {
+				t.Errorf("Drop(%v) = %v, want %v", tt.center, got.Center, tt.want)
+			}
+		})
+	}
+}
+
+func TestRotate(t *testing.T) {
+	iSpec := Spec{
+		Rotations: [][]Coord{
+			{{X: -1, Y: 0}, {X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}},
+			{{X: 1, Y: 1}, {X: 1, Y: 0}, {X: 1, Y: -1}, {X: 1, Y: -2}},
+			{{X: -1, Y: -1}, {X: 0, Y: -1}, {X: 1, Y: -1}, {X: 2, Y: -1}},
+			{{X: 0, Y: 1}, {X: 0, Y: 0}, {X: 0, Y: -1}, {X: 0, Y: -2}},
+		},
+		KickTable: IKickTable,
+	}
+	noKickSpec := Spec{Coords: tShape, KickTable: NoKickTable}
+	customSpec := Spec{
+		Coords:    tShape,
+		KickTable: NoKickTable,
+		Kicks:     []Kick{{From: 1, To: 0, Offsets: []Coord{{X: 0, Y: 0}, {X: 2, Y: 1}}}},
+	}
+
+	tests := []struct {
+		name     string
+		spec     Spec
+		piece    Piece
+		turns    int
+		ok       bool
+		center   Coord
+		rotation int
+		kick     int
+	}{
+		{"in place", tSpec, Piece{Center: Coord{X: 4, Y: 10}, Rotation: 0}, 1, true, Coord{X: 4, Y: 10}, 1, 0},
+		{"off the left wall", tSpec, Piece{Center: Coord{X: 0, Y: 10}, Rotation: 1}, 3, true, Coord{X: 1, Y: 10}, 0, 1},
+		{"without kicks", noKickSpec, Piece{Center: Coord{X: 0, Y: 10}, Rotation: 1}, 3, false, Coord{X: 0, Y: 10}, 1, 0},
+		{"with custom kicks", customSpec, Piece{Center: Coord{X: 0, Y: 10}, Rotation: 1}, 3, true, Coord{X: 2, Y: 9}, 0, 1},
+		{"half turn", tSpec, Piece{Center: Coord{X: 4, Y: 10}, Rotation: 0}, 2, true, Coord{X: 4, Y: 10}, 2, 0},
+		{"I mino", iSpec, Piece{Center: Coord{X: 4, Y: 10}, Rotation: 0}, 1, true, Coord{X: 4, Y: 10}, 1, 0},
+		{"I mino off the right wall", iSpec, Piece{Center: Coord{X: 9, Y: 10}, Rotation: 3}, 1, true, Coord{X: 7, Y: 10}, 0, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := NewBoard(10, 20)
+			tt.piece.Shape = tt.spec.Shape(tt.piece.Rotation)
+			got, ok := Rotate(tt.piece, tt.spec, tt.turns, b.Collides)
+			if ok != tt.ok || got.Center != tt.center || got.Rotation != tt.rotation {
+				t.Fatalf("Rotate() = %v, %v, want center %v rotation %d, %v", got, ok, tt.center, tt.rotation, tt.ok)
+			}
+			if !ok {
+				return
+			}
+			if got.KickIndex != tt.kick || !got.Rotated {
+				t.Errorf("Rotate() = %v, want kick %d", got, tt.kick)
+			}
+			if b.Collides(got.Cells()) {
+				t.Errorf("Rotate() = %v, which collides", got.Cells())
+			}
+		})
+	}
+}
+
+func TestShape(t *testing.T) {
+	tests := []struct {
+		rotation int
+		want     []Coord
+	}{
+		{0, tShape},
+		{1, []Coord{{X: 0, Y: 1}, {X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: -1}}},
+		{2, []Coord{{X: 1, Y: 0}, {X: 0, Y: 0}, {X: 0, Y: -1}, {X: -1, Y: 0}}},
+		{3, []Coord{{X: 0, Y: -1}, {X: 0, Y: 0}, {X: -1, Y: 0}, {X: 0, Y: 1}}},
+	}
+	for _, tt := range tests {
+		if got := tSpec.Shape(tt.rotation); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Shape(%d) = %v, want %v", tt.rotation, got, tt.want)
+		}
+	}
+}
+
+func TestClearRows(t *testing.T) {
+	tests := []struct {
+		name  string
+		cells [][]int
+		piece []Coord
+		rows  []int
+		want  [][]int
+	}{
+		{
+			name:  "no rows",
+			cells: [][]int{{0, 0, 0}, {1, 0, 1}, {1, 1, 0}},
+			piece: []Coord{{X: 0, Y: 1}, {X: 0, Y: 2}},
+			want:  [][]int{{0, 0, 0}, {1, 0, 1}, {1, 1, 0}},
+		},
+		{
+			name:  "bottom row",
+			cells: [][]int{{0, 0, 0}, {2, 0, 0}, {1, 1, 1}},
+			piece: []Coord{{X: 1, Y: 2}},
+			rows:  []int{2},
+			want:  [][]int{{0, 0, 0}, {0, 0, 0}, {2, 0, 0}},
+		},
+		{
+			name:  "row in the middle",
+			cells: [][]int{{3, 0, 0}, {1, 1, 1}, {1, 0, 1}},
+			piece: []Coord{{X: 0, Y: 1}, {X: 0, Y: 2}},
+			rows:  []int{1},
+			want:  [][]int{{0, 0, 0}, {3, 0, 0}, {1, 0, 1}},
+		},
+		{
+			name:  "separate rows",
+			cells: [][]int{{1, 1, 1}, {0, 2, 0}, {1, 1, 1}, {0, 0, 3}},
+			piece: []Coord{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 2}},
+			rows:  []int{0, 2},
+			want:  [][]int{{0, 0, 0}, {0, 0, 0}, {0, 2, 0}, {0, 0, 3}},
+		},
+		{
+			name:  "completed rows out of the piece",
+			cells: [][]int{{0, 0, 0}, {1, 1, 1}, {1, 1, 0}},
+			piece: []Coord{{X: 0, Y: 2}},
+			want:  [][]int{{0, 0, 0}, {1, 1, 1}, {1, 1, 0}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardOf(tt.cells)
+			rows := b.ClearRows(tt.piece)
+			if !reflect.DeepEqual(rows, tt.rows) {
+				t.Errorf("ClearRows() = %v, want %v", rows, tt.rows)
+			}
+			if !reflect.DeepEqual(b.Cells, tt.want) {
+				t.Errorf("cells = %v, want %v", b.Cells, tt.want)
+			}
+		})
+	}
+}
+
+func TestPushGarbage(t *testing.T) {
+	tests := []struct {
+		name  string
+		cells [][]int
+		rows  int
+		ok    bool
+		want  [][]int
+	}{
+		{
+			name:  "one row",
+			cells: [][]int{{0, 0, 0}, {0, 0, 0}, {1, 0, 0}},
+			rows:  1,
+			ok:    true,
+			want:  [][]int{{0, 0, 0}, {1, 0, 0}, {-1, 0, -1}},
+		},
+		{
+			name:  "topped out",
+			cells: [][]int{{0, 0, 0}, {2, 0, 0}, {1, 0, 0}},
+			rows:  2,
+			ok:    false,
+			want:  [][]int{{1, 0, 0}, {-1, 0, -1}, {-1, 0, -1}},
+		},
+		{
+			name:  "more rows than the height",
+			cells: [][]int{{0, 0, 0}, {0, 0, 0}, {0, 0, 0}},
+			rows:  5,
+			ok:    true,
+			want:  [][]int{{-1, 0, -1}, {-1, 0, -1}, {-1, 0, -1}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := boardOf(tt.cells)
+			if ok := b.PushGarbage(tt.rows, 1, -1); ok != tt.ok {
+				t.Errorf("PushGarbage() = %v, want %v", ok, tt.ok)
+			}
+			if !reflect.DeepEqual(b.Cells, tt.want) {
+				t.Errorf("cells = %v, want %v", b.Cells, tt.want)
+			}
+		})
+	}
+}
+
+func TestTSpin(t *testing.T) {
+	pointingDown := tSpec.Shape(2)
+	tests := []struct {
+		name  string
+		cells [][]int
+		piece Piece
+		want  TSpin
+	}{
+		{
+			name: "too few corners",
+			cells: [][]int{
+				{0, 0, 0, 0, 0},
+				{1, 0, 0, 0, 0},
+				{0, 0, 0, 0, 0},
+				{1, 0, 0, 0, 1},
+			},
+			piece: Piece{Center: Coord{X: 2, Y: 2}, Shape: pointingDown, Rotation: 2, Rotated: true},
+			want:  NoTSpin,
+		},
+		{
+			name: "T-spin with 3 corners",
+			cells: [][]int{
+				{0, 0, 0, 0, 0},
+				{0, 1, 0, 0, 0},
+				{0, 0, 0, 0, 0},
+				{0, 1, 0, 1, 0},
+			},
+			piece: Piece{Center: Coord{X: 2, Y: 2}, Shape: pointingDown, Rotation: 2, Rotated: true},
+			want:  TSpinFull,
+		},
+		{
+			name: "T-spin mini with a back corner",
+			cells: [][]int{
+				{0, 0, 0, 0, 0},
+				{0, 1, 0, 1, 0},
+				{0, 0, 0, 0, 0},
+				{0, 1, 0, 0, 0},
+			},
+			piece: Piece{Center: Coord{X: 2, Y: 2}, Shape: pointingDown, Rotation: 2, Rotated: true},
+			want:  TSpinMini,
+		},
+		{
+			name: "T-spin mini upgraded by the last kick",
+			cells: [][]int{
+				{0, 0, 0, 0, 0},
+				{0, 1, 0, 1, 0},
+				{0, 0, 0, 0, 0},
+				{0, 1, 0, 0, 0},
+			},
+			piece: Piece{Center: Coord{X: 2, Y: 2}, Shape: pointingDown, Rotation: 2, Rotated: true, KickIndex: TSpinUpgradeKick},
+			want:  TSpinFull,
+		},
+		{
+			name: "walls",
+			cells: [][]int{
+				{0, 0, 0},
+				{0, 0, 0},
+				{0, 1, 0},
+			},
+			piece: Piece{Center: Coord{X: 0, Y: 1}, Shape: tSpec.Shape(1), Rotation: 1, Rotated: true},
+			want:  TSpinMini,
+		},
+		{
+			name: "not rotated",
+			cells: [][]int{
+				{0, 0, 0, 0, 0},
+				{0, 1, 0, 1, 0},
+				{0, 0, 0, 0, 0},
+				{0, 1, 0, 1, 0},
+			},
+			piece: Piece{Center: Coord{X: 2, Y: 2}, Shape: pointingDown, Rotation: 2},
+			want:  NoTSpin,
+		},
+		{
+			name: "not a T mino",
+			cells: [][]int{
+				{0, 0, 0, 0, 0},
+				{0, 1, 0, 1, 0},
+				{0, 0, 0, 0, 0},
+				{0, 1, 0, 1, 0},
+			},
+			piece: Piece{Center: Coord{X: 2, Y: 2}, Shape: []Coord{{X: -1, Y: 1}, {X: -1, Y: 0}, {X: 0, Y: 0}, {X: 1, Y: 0}}, Rotated: true},
+			want:  NoTSpin,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := boardOf(tt.cells).TSpin(tt.piece); got != tt.want {
+				t.Errorf("TSpin() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
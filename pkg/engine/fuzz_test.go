@@ -0,0 +1,142 @@
+package engine
+
+import (
+	"testing"
+)
+
+// fuzzBoard builds a board of the size from the bytes. Each byte fills a cell if it is odd.
+func fuzzBoard(width, height uint8, data []byte) Board {
+	b := NewBoard(int(width%17)+4, int(height%27)+4)
+	for i, v := range data {
+		if i >= b.Width*b.Height {
+			break
+		}
+		if v%2 == 1 {
+			b.Cells[i/b.Width][i%b.Width] = int(v)
+		}
+	}
+	return b
+}
+
+func countCells(b Board) int {
+	n := 0
+	for _, row := range b.Cells {
+		for _, cell := range row {
+			if cell != 0 {
+				n++
+			}
+		}
+	}
+	return n
+}
+
+func FuzzClearRows(f *testing.F) {
+	f.Add(uint8(0), uint8(0), []byte{1, 1, 1, 1, 0, 1, 1, 1}, uint8(0), uint8(3))
+	f.Add(uint8(6), uint8(16), []byte{}, uint8(2), uint8(4))
+	f.Fuzz(func(t *testing.T, width, height uint8, data []byte, top, rows uint8) {
+		b := fuzzBoard(width, height, data)
+		// Fill the rows from the bottom so that some of them are completed
+		for y := b.Height - 1; y >= b.Height-int(rows)%b.Height; y-- {
+			for x := range b.Cells[y] {
+				if b.Cells[y][x] == 0 && (x+y)%5 != 0 {
+					b.Cells[y][x] = 1
+				}
+			}
+		}
+		var cells []Coord
+		for y := int(top) % b.Height; y < b.Height; y++ {
+			cells = append(cells, Coord{X: 0, Y: y})
+		}
+
+		before := countCells(b)
+		removed := b.ClearRows(cells)
+		for i, y := range removed {
+			if y < int(top)%b.Height || (i > 0 && removed[i-1] >= y) {
+				t.Fatalf("unexpected removed rows %v", removed)
+			}
+		}
+		if after := countCells(b); after != before-len(removed)*b.Width {
+			t.Fatalf("%d cells left after removing %d rows out of %d cells", after, len(removed), before)
+		}
+		// The rows above the checked ones may be completed and come down
+		if int(top)%b.Height != 0 {
+			return
+		}
+		for y, row := range b.Cells {
+			if countCells(Board{Width: b.Width, Height: 1, Cells: [][]int{row}}) == b.Width {
+				t.Fatalf("completed row %d is left", y)
+			}
+		}
+	})
+}
+
+func FuzzMove(f *testing.F) {
+	f.Add(uint8(6), uint8(16), []byte{0, 0, 0, 0, 0, 0, 0, 0, 0, 0, 1, 1}, []byte("lrdcaxLD"))
+	f.Add(uint8(0), uint8(0), []byte{}, []byte("cccc"))
+	f.Fuzz(func(t *testing.T, width, height uint8, data []byte, ops []byte) {
+		b := fuzzBoard(width, height, data)
+		// Keep the spawn area clear
+		for y := 0; y < 3; y++ {
+			for x := range b.Cells[y] {
+				b.Cells[y][x] = 0
+			}
+		}
+		p := Piece{Center: Coord{X: (b.Width - 1) / 2, Y: 1}, Shape: tSpec.Shape(0)}
+
+		for _, op := range ops {
+			var ok bool
+			prev := p
+			switch op % 6 {
+			case 0:
+				p, ok = Move(p, -1, 0, b.Collides)
+			case 1:
+				p, ok = Move(p, 1, 0, b.Collides)
+			case 2:
+				p, ok = Move(p, 0, 1, b.Collides)
+			case 3:
+				p, ok = Rotate(p, tSpec, 1, b.Collides)
+			case 4:
+				p, ok = Rotate(p, tSpec, 3, b.Collides)
+			case 5:
+				p, ok = Rotate(p, tSpec, 2, b.Collides)
+			}
+			if b.Collides(p.Cells()) {
+				t.Fatalf("piece %v collides after op %d", p.Cells(), op%6)
+			}
+			if !ok && (p.Center != prev.Center || p.Rotation != prev.Rotation) {
+				t.Fatalf("blocked op %d moved the piece from %v to %v", op%6, prev, p)
+			}
+		}
+
+		landing := Drop(p, b.Collides)
+		if _, ok := Move(landing, 0, 1, b.Collides); ok {
+			t.Fatalf("dropped piece %v can move down", landing.Cells())
+		}
+		b.Place(landing.Cells(), 7)
+		if countCells(b) < len(landing.Shape) {
+			t.Fatalf("piece %v is not placed", landing.Cells())
+		}
+	})
+}
+
+func FuzzPushGarbage(f *testing.F) {
+	f.Add(uint8(6), uint8(16), []byte{1, 0, 1}, uint8(2), uint8(3))
+	f.Fuzz(func(t *testing.T, width, height uint8, data []byte, rows, hole uint8) {
+		b := fuzzBoard(width, height, data)
+		n := int(rows) % (b.Height + 3)
+		before := countCells(b)
+		b.PushGarbage(n, int(hole)%b.Width, -1)
+
+		if n > b.Height {
+			n = b.Height
+		}
+		for y := b.Height - n; y < b.Height; y++ {
+			if b.Cells[y][int(hole)%b.Width] != 0 || countCells(Board{Width: b.Width, Height: 1, Cells: b.Cells[y : y+1]}) != b.Width-1 {
+				t.Fatalf("garbage row %d is %v", y, b.Cells[y])
+			}
+		}
+		if countCells(b) > before+n*(b.Width-1) {
+			t.Fatalf("%d cells after pushing %d rows to %d cells", countCells(b), n, before)
+		}
+	})
+}
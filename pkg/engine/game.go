@@ -0,0 +1,464 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"time"
+)
+
+// Mino is a kind of mino which can be dealt to the players.
+type Mino struct {
+	ID   int
+	Spec Spec
+}
+
+// Current is the falling piece of a player.
+type Current struct {
+	// ID of the mino
+	MinoID int
+	// ID of the player who moves the piece
+	Player string
+	Piece  Piece
+	// Time when the lock delay started. It is zero while the lock delay is not running.
+	LockStart time.Time
+	// Number of times the lock delay has been reset by moving or rotating the piece on the ground
+	LockResets int
+	// Whether the hold has been used since the piece appeared
+	HoldUsed bool
+}
+
+// Result is the result of an op applied to a falling piece.
+type Result string
+
+const (
+	// The op is applied
+	ResultApplied Result = "Applied"
+	// The op cannot be applied because the piece has no space to move or the hold cannot be used
+	ResultBlocked Result = "Blocked"
+	// The op is not applied to any piece
+	ResultIgnored Result = "Ignored"
+	// The op is unknown
+	ResultInvalid Result = "Invalid"
+)
+
+// End is the reason why the game is over.
+type End string
+
+const (
+	// The game is being played
+	NotOver End = ""
+	// A new piece has no space to appear
+	NoSpace End = "NoSpace"
+	// The minoes in the sequence have run out and no piece is left
+	OutOfMinoes End = "OutOfMinoes"
+	// The garbage rows have pushed the blocks out of the top of the board
+	ToppedOut End = "ToppedOut"
+)
+
+// Game is the state of a game: the board, the falling pieces, the queue of the next minoes, the hold, the score and the garbage rows.
+// Its methods implement the state machine of the game. They take the current time from the caller, so that the game only depends on its inputs.
+type Game struct {
+	Board Board
+	// Minoes which can be dealt, in the order of their IDs
+	Minoes []Mino
+	// IDs of the players who have their own falling piece. A game without players is played by a single player whose ID is empty.
+	Players []string
+	// Number of minoes kept in the next queue
+	NextCount int
+	// How the minoes are dealt at random
+	Randomizer Randomizer
+	// IDs of the minoes dealt in order instead of at random. No more mino is dealt after the sequence runs out.
+	Sequence []int
+	// Time to wait before a piece on the ground is fixed. If it is not positive, the piece is fixed as soon as it moves down onto the ground.
+	LockDelay time.Duration
+	// Number of times the lock delay can be reset while a piece is on the ground
+	MaxLockResets int
+	// Whether the line clears send garbage rows to the opponents
+	Versus bool
+
+	// Falling pieces of the players
+	Current []Current
+	// ID of the held mino. 0 if nothing is held.
+	Held int
+	// IDs of the next minoes
+	Next []int
+	// IDs of the minoes left in the bag of the Bag randomizer
+	Bag []int
+	// Number of the minoes dealt from the sequence
+	SequenceIndex int
+	// State of the random number generator
+	RandomState int64
+
+	Score      int
+	Lines      int
+	Level      int
+	Pieces     int
+	Combo      int
+	BackToBack int
+	// Type of the last line clear or T-spin
+	LastClear ClearType
+	// Rows removed by the last fixed piece
+	ClearedRows []int
+	// Number of the garbage rows received and not pushed into the board yet
+	PendingGarbage int
+	// Total number of the garbage rows sent to the opponents
+	GarbageSent int
+
+	// Why the game is over
+	End End
+}
+
+// Landing is the result of fixing a piece on the board.
+type Landing struct {
+	// ID of the player who moved the piece
+	Player string
+	// Number of the removed rows
+	Rows int
+	// Type of the line clear. It is empty if the piece made neither a line clear nor a T-spin.
+	Clear ClearType
+}
+
+// Number of clockwise quarter turns for each rotation op.
+var rotationTurns = map[string]int{
+	"rotate":    1,
+	"rotate180": 2,
+	"rotateCCW": 3,
+}
+
+// players returns the IDs of the players who have their own falling piece.
+func (g *Game) players() []string {
+	if len(g.Players) == 0 {
+		return []string{""}
+	}
+	return g.Players
+}
+
+// Find returns the index of the falling piece of the player, or -1 if the player has none.
+func (g *Game) Find(player string) int {
+	for i, c := range g.Current {
+		if c.Player == player {
+			return i
+		}
+	}
+	return -1
+}
+
+// remove removes the i-th falling piece.
+func (g *Game) remove(i int) {
+	g.Current = append(g.Current[:i:i], g.Current[i+1:]...)
+}
+
+// over finishes the game for the reason.
+func (g *Game) over(end End) {
+	g.Current = nil
+	g.End = end
+}
+
+// mino returns the mino which has the ID.
+func (g *Game) mino(id int) (Mino, bool) {
+	for _, m := range g.Minoes {
+		if m.ID == id {
+			return m, true
+		}
+	}
+	return Mino{}, false
+}
+
+// collidesWithTeammates returns true if the cells overlap any falling piece other than the i-th one.
+func (g *Game) collidesWithTeammates(i int, cells []Coord) bool {
+	for j, c := range g.Current {
+		if j == i {
+			continue
+		}
+		for _, cell := range c.Piece.Cells() {
+			for _, coord := range cells {
+				if coord == cell {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// blocked returns the function which tells whether the cells are blocked for the i-th falling piece,
+// by the blocks on the board or by the teammates' pieces.
+func (g *Game) blocked(i int) Blocked {
+	return func(cells []Coord) bool {
+		return g.Board.Collides(cells) || g.collidesWithTeammates(i, cells)
+	}
+}
+
+// grounded returns true if the i-th falling piece cannot move down any more because of the blocks on the board.
+func (g *Game) grounded(i int) bool {
+	return g.Board.Collides(g.Current[i].Piece.Moved(0, 1).Cells())
+}
+
+// spawnCenter returns the center where a new piece of the player appears.
+// The players divide the width of the board equally and their pieces appear in the middle of their own parts.
+func (g *Game) spawnCenter(player string) Coord {
+	ids := g.players()
+	n := len(ids)
+	for k, id := range ids {
+		if id == player {
+			return Coord{X: (g.Board.Width*(2*k+1)/n - 1) / 2, Y: 2}
+		}
+	}
+	return Coord{X: (g.Board.Width - 1) / 2, Y: 2}
+}
+
+// newPiece returns the piece of the mino for the player, placed at the top of the board.
+func (g *Game) newPiece(player string, mino Mino) Current {
+	return Current{
+		MinoID: mino.ID,
+		Player: player,
+		Piece:  Piece{Center: g.spawnCenter(player), Shape: mino.Spec.Shape(0)},
+	}
+}
+
+// spawn puts the piece as the falling piece of its player. It returns false if there is no space for it.
+func (g *Game) spawn(c Current) bool {
+	if g.Board.Collides(c.Piece.Cells()) {
+		return false
+	}
+	if i := g.Find(c.Player); i >= 0 {
+		g.Current[i] = c
+	} else {
+		g.Current = append(g.Current, c)
+	}
+	return true
+}
+
+// deal takes the next mino and puts it as the falling piece of the player. It returns false if there is no space for it.
+// It also returns true as the second value if no mino is left in the sequence.
+// While a teammate's piece is in the way, the next mino waits in the queue and no piece appears.
+func (g *Game) deal(player string) (bool, bool) {
+	ids := make([]int, len(g.Minoes))
+	for i, m := range g.Minoes {
+		ids[i] = m.ID
+	}
+	g.retainMinoIDs(ids)
+
+	id := g.nextMinoID(g.generator(ids))
+	if id == 0 {
+		return false, true
+	}
+	mino, _ := g.mino(id)
+	c := g.newPiece(player, mino)
+	cells := c.Piece.Cells()
+	if !g.Board.Collides(cells) && g.collidesWithTeammates(-1, cells) {
+		g.Next = append([]int{id}, g.Next...)
+		return true, false
+	}
+	return g.spawn(c), false
+}
+
+// Deal removes the pieces of the players who have left, and deals a new piece to each player who has none.
+// The game is over if a new piece has no space to appear. When the minoes in the sequence run out,
+// the game is over after the pieces of all the players have landed.
+func (g *Game) Deal() {
+	if g.End != NotOver {
+		return
+	}
+
+	ids := g.players()
+	for i := len(g.Current) - 1; i >= 0; i-- {
+		if !containsString(ids, g.Current[i].Player) {
+			g.remove(i)
+		}
+	}
+	outOfMinoes := false
+	for _, player := range ids {
+		if g.Find(player) >= 0 {
+			continue
+		}
+		ok, out := g.deal(player)
+		if out {
+			// The teammates go on with their pieces
+			outOfMinoes = true
+			continue
+		}
+		if !ok {
+			g.over(NoSpace)
+			return
+		}
+	}
+	if outOfMinoes && len(g.Current) == 0 {
+		g.over(OutOfMinoes)
+	}
+}
+
+// Hold swaps the i-th falling piece with the held mino, or holds it and takes the next mino if nothing is held.
+// The hold can be used only once until the piece lands.
+func (g *Game) Hold(i int) Result {
+	current := g.Current[i]
+	if current.HoldUsed {
+		return ResultBlocked
+	}
+
+	held := g.Held
+	if held == 0 && len(g.Sequence) != 0 && len(g.Next) == 0 {
+		// No mino is left to take out
+		return ResultBlocked
+	}
+	heldMino, found := g.mino(held)
+	var taken Current
+	if found {
+		taken = g.newPiece(current.Player, heldMino)
+		if g.collidesWithTeammates(i, taken.Piece.Cells()) {
+			return ResultBlocked
+		}
+	}
+	g.Held = current.MinoID
+	g.remove(i)
+
+	var ok bool
+	if found {
+		ok = g.spawn(taken)
+	} else {
+		ok, _ = g.deal(current.Player)
+	}
+	if !ok {
+		g.over(NoSpace)
+		return ResultApplied
+	}
+	j := g.Find(current.Player)
+	if j < 0 {
+		// The next mino waits for a teammate's piece to move away, so the hold is undone
+		g.Held = held
+		g.Current = append(g.Current[:i:i], append([]Current{current}, g.Current[i:]...)...)
+		return ResultBlocked
+	}
+	g.Current[j].HoldUsed = true
+	return ResultApplied
+}
+
+// Apply applies the op to the i-th falling piece at the time `now`. byUser tells whether the op is requested by the user.
+// It also returns the landing if the piece is fixed. The next piece is not dealt until Deal is called.
+func (g *Game) Apply(i int, op string, byUser bool, now time.Time) (Result, *Landing) {
+	c := &g.Current[i]
+
+	switch op {
+	case "down":
+		moved := c.Piece.Moved(0, 1)
+		if g.Board.Collides(moved.Cells()) {
+			if g.LockDelay > 0 {
+				g.startLockDelay(i, now)
+				if g.LockRemaining(i, now) > 0 {
+					return ResultBlocked, nil
+				}
+			}
+			landing := g.Land(i)
+			return ResultApplied, &landing
+		}
+		if g.collidesWithTeammates(i, moved.Cells()) {
+			// The piece is not fixed on a teammate's piece, which is still falling
+			return ResultBlocked, nil
+		}
+		c.Piece = moved
+		if byUser {
+			g.Score += SoftDropScore
+		}
+		g.startLockDelay(i, now)
+
+	case "left", "right":
+		dx := 1
+		if op == "left" {
+			dx = -1
+		}
+		moved, ok := Move(c.Piece, dx, 0, g.blocked(i))
+		if !ok {
+			return ResultBlocked, nil
+		}
+		c.Piece = moved
+		g.resetLockDelay(i, now)
+
+	case "rotate", "rotateCCW", "rotate180":
+		mino, ok := g.mino(c.MinoID)
+		if !ok {
+			return ResultIgnored, nil
+		}
+		rotated, ok := Rotate(c.Piece, mino.Spec, rotationTurns[op], g.blocked(i))
+		if !ok {
+			return ResultBlocked, nil
+		}
+		c.Piece = rotated
+		g.resetLockDelay(i, now)
+
+	case "drop":
+		dropped := Drop(c.Piece, g.blocked(i))
+		g.Score += (dropped.Center.Y - c.Piece.Center.Y) * HardDropScore
+		c.Piece = dropped
+		landing := g.Land(i)
+		return ResultApplied, &landing
+
+	default:
+		return ResultInvalid, nil
+	}
+	return ResultApplied, nil
+}
+
+// Land fixes the i-th falling piece on the board, removes the completed rows and updates the score.
+// In versus mode, it sends the garbage rows for the line clear, and pushes the pending ones into the board if no rows are removed.
+func (g *Game) Land(i int) Landing {
+	c := g.Current[i]
+	// The corners are checked before the piece is fixed
+	spin := g.Board.TSpin(c.Piece)
+	cells := c.Piece.Cells()
+	g.Board.Place(cells, c.MinoID)
+	g.remove(i)
+	g.ClearedRows = g.Board.ClearRows(cells)
+	rows := len(g.ClearedRows)
+	landing := Landing{Player: c.Player, Rows: rows, Clear: g.addLineClear(rows, spin)}
+	g.Pieces++
+
+	if g.Versus {
+		g.sendGarbage(landing.Clear)
+		// The garbage rows come up only when the piece removes no rows
+		if rows == 0 && !g.pushGarbage() {
+			g.over(ToppedOut)
+			return landing
+		}
+	}
+	g.liftPieces()
+	return landing
+}
+
+// liftPieces moves the falling pieces up out of the blocks after the rows of the board are shifted.
+func (g *Game) liftPieces() {
+	for i := range g.Current {
+		p := &g.Current[i].Piece
+		for p.Center.Y > 0 && g.Board.Collides(p.Cells()) {
+			p.Center.Y--
+		}
+	}
+}
+
+// Ghost returns the i-th falling piece moved down as far as it can go.
+func (g *Game) Ghost(i int) Piece {
+	return Drop(g.Current[i].Piece, g.blocked(i))
+}
+
+// containsString returns true if the list contains the string.
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,214 @@
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+// O mino with the center at its bottom left cell
+var oShape = []Coord{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 1}}
+
+var testMinoes = []Mino{{ID: 2, Spec: Spec{Coords: oShape}}, {ID: 7, Spec: tSpec}}
+
+// newTestGame returns a game on an empty board with the falling pieces.
+func newTestGame(width, height int, current ...Current) *Game {
+	return &Game{
+		Board:   NewBoard(width, height),
+		Minoes:  testMinoes,
+		Level:   1,
+		Current: current,
+	}
+}
+
+func TestGameRandomizer(t *testing.T) {
+	ids := []int{1, 2, 3, 4, 5, 6, 7}
+	for _, randomizer := range []Randomizer{Uniform, Bag} {
+		t.Run(string(randomizer), func(t *testing.T) {
+			var dealt [2][]int
+			for i := range dealt {
+				g := &Game{Randomizer: randomizer, NextCount: 3, RandomState: 12345}
+				for j := 0; j < 50; j++ {
+					dealt[i] = append(dealt[i], g.nextMinoID(g.generator(ids)))
+				}
+			}
+			if !reflect.DeepEqual(dealt[0], dealt[1]) {
+				t.Errorf("dealt %v and %v from the same seed", dealt[0], dealt[1])
+			}
+		})
+	}
+
+	t.Run("every mino once in a bag", func(t *testing.T) {
+		g := &Game{Randomizer: Bag, NextCount: 3, RandomState: 1}
+		for i := 0; i < 3; i++ {
+			seen := make(map[int]bool)
+			for j := 0; j < len(ids); j++ {
+				seen[g.nextMinoID(g.generator(ids))] = true
+			}
+			if len(seen) != len(ids) {
+				t.Errorf("bag %d = %v, want all of %v", i, seen, ids)
+			}
+		}
+	})
+}
+
+func TestGameSequence(t *testing.T) {
+	g := &Game{NextCount: 2, Sequence: []int{2, 9, 1, 2}}
+	gen := g.generator([]int{1, 2})
+	if id := g.nextMinoID(gen); id != 2 {
+		t.Errorf("nextMinoID() = %d, want 2", id)
+	}
+	if !reflect.DeepEqual(g.Next, []int{1, 2}) {
+		t.Errorf("Next = %v, want [1 2] without the unknown mino", g.Next)
+	}
+	for _, want := range []int{1, 2, 0} {
+		if id := g.nextMinoID(gen); id != want {
+			t.Errorf("nextMinoID() = %d, want %d", id, want)
+		}
+	}
+}
+
+func TestGameApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		op       string
+		minoID   int
+		result   Result
+		center   Coord
+		rotation int
+	}{
+		{"left", "left", 7, ResultApplied, Coord{X: 3, Y: 10}, 0},
+		{"right", "right", 7, ResultApplied, Coord{X: 5, Y: 10}, 0},
+		{"down", "down", 7, ResultApplied, Coord{X: 4, Y: 11}, 0},
+		{"rotate", "rotate", 7, ResultApplied, Coord{X: 4, Y: 10}, 1},
+		{"rotate counterclockwise", "rotateCCW", 7, ResultApplied, Coord{X: 4, Y: 10}, 3},
+		{"rotate a half turn", "rotate180", 7, ResultApplied, Coord{X: 4, Y: 10}, 2},
+		{"rotate an unknown mino", "rotate", 9, ResultIgnored, Coord{X: 4, Y: 10}, 0},
+		{"unknown op", "jump", 7, ResultInvalid, Coord{X: 4, Y: 10}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := newTestGame(10, 20, Current{MinoID: tt.minoID, Piece: Piece{Center: Coord{X: 4, Y: 10}, Shape: tShape}})
+			result, landing := g.Apply(0, tt.op, false, time.Now())
+			if result != tt.result || landing != nil {
+				t.Fatalf("Apply(%q) = %v, %v, want %v", tt.op, result, landing, tt.result)
+			}
+			p := g.Current[0].Piece
+			if p.Center != tt.center || p.Rotation != tt.rotation {
+				t.Errorf("Apply(%q) moved the piece to %v rotation %d, want %v rotation %d", tt.op, p.Center, p.Rotation, tt.center, tt.rotation)
+			}
+		})
+	}
+}
+
+func TestGameLockDelay(t *testing.T) {
+	now := time.Now()
+	newGame := func() *Game {
+		g := newTestGame(6, 5, Current{MinoID: 2, Piece: Piece{Center: Coord{X: 2, Y: 4}, Shape: oShape}})
+		g.LockDelay = 500 * time.Millisecond
+		g.MaxLockResets = 1
+		return g
+	}
+
+	t.Run("fix the piece after the lock delay", func(t *testing.T) {
+		g := newGame()
+		if result, _ := g.Apply(0, "down", false, now); result != ResultBlocked {
+			t.Fatalf("Apply(down) = %v, want %v", result, ResultBlocked)
+		}
+		if remaining := g.LockRemaining(0, now.Add(200*time.Millisecond)); remaining != 300*time.Millisecond {
+			t.Errorf("LockRemaining() = %v, want 300ms", remaining)
+		}
+		if landings, next := g.Lock(now.Add(200 * time.Millisecond)); len(landings) != 0 || next != 300*time.Millisecond {
+			t.Errorf("Lock() = %v, %v, want no landings and 300ms", landings, next)
+		}
+		if landings, next := g.Lock(now.Add(time.Second)); len(landings) != 1 || next != 0 {
+			t.Errorf("Lock() = %v, %v, want a landing", landings, next)
+		}
+		if len(g.Current) != 0 || g.Pieces != 1 || g.Board.Cells[4][2] != 2 {
+			t.Errorf("the piece is not fixed: %v, %d pieces", g.Current, g.Pieces)
+		}
+	})
+
+	t.Run("reset the lock delay up to the limit", func(t *testing.T) {
+		g := newGame()
+		g.Apply(0, "down", false, now)
+		later := now.Add(time.Second)
+		g.Apply(0, "left", false, later)
+		if g.Current[0].LockResets != 1 || g.LockRemaining(0, later) == 0 {
+			t.Errorf("LockResets = %d, LockRemaining() = %v, want the lock delay reset", g.Current[0].LockResets, g.LockRemaining(0, later))
+		}
+		g.Apply(0, "left", false, later.Add(time.Second))
+		if g.LockRemaining(0, later.Add(time.Second)) != 0 {
+			t.Errorf("the lock delay is reset over the limit")
+		}
+	})
+
+	t.Run("do nothing without lock delay", func(t *testing.T) {
+		g := newGame()
+		g.LockDelay = 0
+		g.Apply(0, "left", false, now)
+		if !g.Current[0].LockStart.IsZero() {
+			t.Errorf("the lock delay is started")
+		}
+		if result, landing := g.Apply(0, "down", false, now); result != ResultApplied || landing == nil {
+			t.Errorf("Apply(down) = %v, %v, want a landing", result, landing)
+		}
+	})
+}
+
+func TestGameSendGarbage(t *testing.T) {
+	g := &Game{}
+	g.sendGarbage(ClearSingle)
+	if g.GarbageSent != 0 {
+		t.Errorf("GarbageSent = %d after a single, want 0", g.GarbageSent)
+	}
+
+	g.PendingGarbage = 3
+	g.sendGarbage(ClearTSpinDouble)
+	if g.PendingGarbage != 0 || g.GarbageSent != 1 {
+		t.Errorf("PendingGarbage = %d, GarbageSent = %d, want the pending rows offset", g.PendingGarbage, g.GarbageSent)
+	}
+
+	g.BackToBack = 2
+	g.sendGarbage(ClearQuad)
+	if g.GarbageSent != 6 {
+		t.Errorf("GarbageSent = %d, want 6 with the back-to-back bonus", g.GarbageSent)
+	}
+}
+
+func TestGamePushGarbage(t *testing.T) {
+	g := newTestGame(4, 5)
+	g.RandomState = 1
+	g.Board.Cells[4] = []int{1, 1, 0, 1}
+	g.PendingGarbage = 2
+	if !g.pushGarbage() {
+		t.Fatalf("pushGarbage() = false, want true")
+	}
+	if g.PendingGarbage != 0 {
+		t.Errorf("PendingGarbage = %d, want 0", g.PendingGarbage)
+	}
+	if !reflect.DeepEqual(g.Board.Cells[2], []int{1, 1, 0, 1}) {
+		t.Errorf("row 2 = %v, want the pushed row", g.Board.Cells[2])
+	}
+	if !reflect.DeepEqual(g.Board.Cells[3], g.Board.Cells[4]) {
+		t.Errorf("rows 3 and 4 = %v, %v, want the same hole", g.Board.Cells[3], g.Board.Cells[4])
+	}
+	holes := 0
+	for _, cell := range g.Board.Cells[4] {
+		switch cell {
+		case 0:
+			holes++
+		case GarbageCell:
+		default:
+			t.Errorf("row 4 = %v, want garbage cells", g.Board.Cells[4])
+		}
+	}
+	if holes != 1 {
+		t.Errorf("row 4 = %v, want a hole", g.Board.Cells[4])
+	}
+
+	g.PendingGarbage = 3
+	if g.pushGarbage() {
+		t.Errorf("pushGarbage() = true, want false when the blocks are pushed out of the top")
+	}
+}
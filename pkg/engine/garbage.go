@@ -0,0 +1,57 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+// GarbageCell is the value of the cells of the garbage rows.
+const GarbageCell = -1
+
+// Number of garbage rows sent to the opponents for each type of line clear in versus mode.
+var garbageRows = map[ClearType]int{
+	ClearDouble:          1,
+	ClearTriple:          2,
+	ClearQuad:            4,
+	ClearTSpinMiniDouble: 1,
+	ClearTSpinSingle:     2,
+	ClearTSpinDouble:     4,
+	ClearTSpinTriple:     6,
+}
+
+// sendGarbage sends the garbage rows for the line clear to the opponents. They offset the pending garbage rows first.
+func (g *Game) sendGarbage(clear ClearType) {
+	rows := garbageRows[clear]
+	if rows > 0 && g.BackToBack > 1 {
+		rows++
+	}
+	offset := rows
+	if offset > g.PendingGarbage {
+		offset = g.PendingGarbage
+	}
+	g.PendingGarbage -= offset
+	g.GarbageSent += rows - offset
+}
+
+// pushGarbage pushes the pending garbage rows into the bottom of the board, with a hole at a random column.
+// It returns false if any block is pushed out of the top, which means the board has topped out.
+func (g *Game) pushGarbage() bool {
+	rows := g.PendingGarbage
+	if rows == 0 {
+		return true
+	}
+	g.PendingGarbage = 0
+	hole := g.rand().Intn(g.Board.Width)
+	return g.Board.PushGarbage(rows, hole, GarbageCell)
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"math/rand"
+)
+
+// Randomizer defines how the minoes are dealt at random.
+type Randomizer string
+
+const (
+	// Every mino is picked at random independently
+	Uniform Randomizer = "Uniform"
+	// All the minoes are dealt in random order before any of them repeats
+	Bag Randomizer = "Bag"
+)
+
+// generator generates the IDs of the minoes in the order they are dealt.
+type generator interface {
+	next() int
+}
+
+// uniformGenerator picks every mino at random independently.
+type uniformGenerator struct {
+	ids []int
+	rnd *rand.Rand
+}
+
+func (gen *uniformGenerator) next() int {
+	return gen.ids[gen.rnd.Intn(len(gen.ids))]
+}
+
+// bagGenerator deals all the minoes in random order before any of them repeats.
+// The minoes left in the bag are kept in Game.Bag.
+type bagGenerator struct {
+	ids  []int
+	rnd  *rand.Rand
+	game *Game
+}
+
+func (gen *bagGenerator) next() int {
+	if len(gen.game.Bag) == 0 {
+		bag := append([]int{}, gen.ids...)
+		gen.rnd.Shuffle(len(bag), func(i, j int) {
+			bag[i], bag[j] = bag[j], bag[i]
+		})
+		gen.game.Bag = bag
+	}
+	id := gen.game.Bag[0]
+	gen.game.Bag = gen.game.Bag[1:]
+	return id
+}
+
+// sequenceGenerator deals the minoes in Game.Sequence in order. It returns 0 after the sequence runs out.
+// The number of the minoes dealt is kept in Game.SequenceIndex.
+type sequenceGenerator struct {
+	ids  []int
+	game *Game
+}
+
+func (gen *sequenceGenerator) next() int {
+	for gen.game.SequenceIndex < len(gen.game.Sequence) {
+		id := gen.game.Sequence[gen.game.SequenceIndex]
+		gen.game.SequenceIndex++
+		// skip the minoes which don't exist
+		if containsInt(gen.ids, id) {
+			return id
+		}
+	}
+	return 0
+}
+
+// generator returns the generator which deals the minoes with the IDs.
+func (g *Game) generator(ids []int) generator {
+	if len(g.Sequence) != 0 {
+		return &sequenceGenerator{ids: ids, game: g}
+	}
+	rnd := g.rand()
+	if g.Randomizer == Uniform {
+		return &uniformGenerator{ids: ids, rnd: rnd}
+	}
+	return &bagGenerator{ids: ids, rnd: rnd, game: g}
+}
+
+// nextMinoID takes the first mino from the next queue and fills the queue up to NextCount.
+// It returns 0 if no mino is left.
+func (g *Game) nextMinoID(gen generator) int {
+	for len(g.Next) <= g.NextCount {
+		id := gen.next()
+		if id == 0 {
+			break
+		}
+		g.Next = append(g.Next, id)
+	}
+	if len(g.Next) == 0 {
+		return 0
+	}
+	id := g.Next[0]
+	g.Next = g.Next[1:]
+	return id
+}
+
+// retainMinoIDs drops the IDs of the minoes which no longer exist from the next queue and the bag.
+func (g *Game) retainMinoIDs(ids []int) {
+	filter := func(list []int) []int {
+		var retained []int
+		for _, id := range list {
+			if containsInt(ids, id) {
+				retained = append(retained, id)
+			}
+		}
+		return retained
+	}
+	g.Next = filter(g.Next)
+	g.Bag = filter(g.Bag)
+}
+
+// containsInt returns true if the list contains the value.
+func containsInt(list []int, v int) bool {
+	for _, x := range list {
+		if x == v {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"time"
+)
+
+// LockRemaining returns the time left at `now` until the i-th falling piece is fixed. It is 0 when the lock delay is over or not running.
+func (g *Game) LockRemaining(i int, now time.Time) time.Duration {
+	start := g.Current[i].LockStart
+	if start.IsZero() {
+		return 0
+	}
+	if remaining := start.Add(g.LockDelay).Sub(now); remaining > 0 {
+		return remaining
+	}
+	return 0
+}
+
+// startLockDelay starts the lock delay if the i-th falling piece is on the ground.
+// It does nothing when the lock delay is disabled, so that the piece is fixed only by moving it down.
+func (g *Game) startLockDelay(i int, now time.Time) {
+	if g.LockDelay <= 0 {
+		return
+	}
+	c := &g.Current[i]
+	if c.LockStart.IsZero() && g.grounded(i) {
+		c.LockStart = now
+	}
+}
+
+// resetLockDelay resets the lock delay after the i-th falling piece is moved or rotated,
+// unless the number of resets reaches MaxLockResets while the piece is still on the ground.
+// The lock delay is always cleared when the piece leaves the ground, and starts over when it lands again.
+func (g *Game) resetLockDelay(i int, now time.Time) {
+	c := &g.Current[i]
+	if !c.LockStart.IsZero() {
+		if c.LockResets >= g.MaxLockResets && g.grounded(i) {
+			return
+		}
+		if c.LockResets < g.MaxLockResets {
+			c.LockResets++
+		}
+		c.LockStart = time.Time{}
+	}
+	g.startLockDelay(i, now)
+}
+
+// StopLockDelay clears the lock delay of all the falling pieces. It starts over when they move down.
+func (g *Game) StopLockDelay() {
+	for i := range g.Current {
+		g.Current[i].LockStart = time.Time{}
+	}
+}
+
+// Lock fixes the falling pieces on the ground whose lock delay is over at `now`.
+// It returns their landings, and the time left until the next piece is fixed, which is 0 if no lock delay is running.
+func (g *Game) Lock(now time.Time) ([]Landing, time.Duration) {
+	var landings []Landing
+	var next time.Duration
+	// Landing removes the piece from the list, so the list is scanned from the end
+	for i := len(g.Current) - 1; i >= 0 && g.End == NotOver; i-- {
+		if g.Current[i].LockStart.IsZero() || !g.grounded(i) {
+			continue
+		}
+		if remaining := g.LockRemaining(i, now); remaining > 0 {
+			if next == 0 || remaining < next {
+				next = remaining
+			}
+			continue
+		}
+		landings = append(landings, g.Land(i))
+	}
+	if g.End != NotOver {
+		return landings, 0
+	}
+	return landings, next
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+// Piece is a falling mino.
+type Piece struct {
+	// Absolute coordinate of the center
+	Center Coord
+	// Coordinates of the cells relative to the center
+	Shape []Coord
+	// Rotation state from 0 (spawn) to 3, counted in clockwise quarter turns
+	Rotation int
+	// Whether the last successful move was a rotation
+	Rotated bool
+	// Index of the wall kick offset used by the last rotation
+	KickIndex int
+}
+
+// Cells returns the absolute coordinates of the cells of the piece.
+func (p Piece) Cells() []Coord {
+	var cells []Coord
+	for _, c := range p.Shape {
+		cells = append(cells, Coord{X: p.Center.X + c.X, Y: p.Center.Y - c.Y})
+	}
+	return cells
+}
+
+// Moved returns the piece shifted by dx to the right and dy downward.
+func (p Piece) Moved(dx, dy int) Piece {
+	p.Center.X += dx
+	p.Center.Y += dy
+	p.Rotated = false
+	return p
+}
+
+// Move shifts the piece by dx to the right and dy downward.
+// It returns the piece as it is and false if the destination is blocked.
+func Move(p Piece, dx, dy int, blocked Blocked) (Piece, bool) {
+	moved := p.Moved(dx, dy)
+	if blocked(moved.Cells()) {
+		return p, false
+	}
+	return moved, true
+}
+
+// Drop returns the piece moved down as far as it can go.
+func Drop(p Piece, blocked Blocked) Piece {
+	for {
+		next, ok := Move(p, 0, 1, blocked)
+		if !ok {
+			return p
+		}
+		p = next
+	}
+}
+
+// Rotate rotates the piece clockwise by the given number of quarter turns.
+// The kick offsets of the spec are tried in order and the first one which is not blocked is applied.
+// It returns the piece as it is and false if all of them are blocked.
+func Rotate(p Piece, spec Spec, turns int, blocked Blocked) (Piece, bool) {
+	from := p.Rotation
+	to := ((from+turns)%4 + 4) % 4
+	shape := spec.Shape(to)
+
+	for k, offset := range spec.KickOffsets(from, to) {
+		rotated := Piece{
+			Center:    Coord{X: p.Center.X + offset.X, Y: p.Center.Y - offset.Y},
+			Shape:     shape,
+			Rotation:  to,
+			Rotated:   true,
+			KickIndex: k,
+		}
+		if !blocked(rotated.Cells()) {
+			return rotated, true
+		}
+	}
+	return p, false
+}
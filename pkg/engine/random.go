@@ -0,0 +1,49 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+import (
+	"math/rand"
+)
+
+// source is a rand.Source64 (splitmix64) which keeps its state in Game.RandomState,
+// so that the random numbers of a game only depend on the seed, however many times the game is saved and restored.
+type source struct {
+	game *Game
+}
+
+func (s *source) Uint64() uint64 {
+	state := uint64(s.game.RandomState) + 0x9e3779b97f4a7c15
+	s.game.RandomState = int64(state)
+	z := state
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+func (s *source) Int63() int64 {
+	return int64(s.Uint64() >> 1)
+}
+
+func (s *source) Seed(seed int64) {
+	s.game.RandomState = seed
+}
+
+// rand returns the random number generator of the game.
+func (g *Game) rand() *rand.Rand {
+	return rand.New(&source{game: g})
+}
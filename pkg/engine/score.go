@@ -0,0 +1,132 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+// ClearType is the type of a line clear or a T-spin. The values are the same as the ClearType of the Board API.
+type ClearType string
+
+const (
+	ClearSingle          ClearType = "Single"
+	ClearDouble          ClearType = "Double"
+	ClearTriple          ClearType = "Triple"
+	ClearQuad            ClearType = "Quad"
+	ClearTSpinMini       ClearType = "TSpinMini"
+	ClearTSpinMiniSingle ClearType = "TSpinMiniSingle"
+	ClearTSpinMiniDouble ClearType = "TSpinMiniDouble"
+	ClearTSpin           ClearType = "TSpin"
+	ClearTSpinSingle     ClearType = "TSpinSingle"
+	ClearTSpinDouble     ClearType = "TSpinDouble"
+	ClearTSpinTriple     ClearType = "TSpinTriple"
+)
+
+const (
+	// Number of cleared lines required to go up one level.
+	LinesPerLevel = 10
+
+	// Points per cell for a soft drop requested by the user.
+	SoftDropScore = 1
+
+	// Points per cell for a hard drop.
+	HardDropScore = 2
+
+	// Points per combo count after the first line clear of a chain. They are multiplied by the current level.
+	ComboScore = 50
+)
+
+// Base points for each type of line clear and T-spin. They are multiplied by the current level.
+var clearScores = map[ClearType]int{
+	ClearSingle:          100,
+	ClearDouble:          300,
+	ClearTriple:          500,
+	ClearQuad:            800,
+	ClearTSpinMini:       100,
+	ClearTSpinMiniSingle: 200,
+	ClearTSpinMiniDouble: 400,
+	ClearTSpin:           400,
+	ClearTSpinSingle:     800,
+	ClearTSpinDouble:     1200,
+	ClearTSpinTriple:     1600,
+}
+
+// Types of line clears indexed by the number of removed rows, for each kind of T-spin.
+var clearTypes = map[TSpin][]ClearType{
+	NoTSpin:   {"", ClearSingle, ClearDouble, ClearTriple, ClearQuad},
+	TSpinMini: {ClearTSpinMini, ClearTSpinMiniSingle, ClearTSpinMiniDouble},
+	TSpinFull: {ClearTSpin, ClearTSpinSingle, ClearTSpinDouble, ClearTSpinTriple},
+}
+
+// ClearTypes returns all the types of line clears and T-spins.
+func ClearTypes() []ClearType {
+	var types []ClearType
+	for _, t := range clearTypes {
+		for _, clear := range t {
+			if clear != "" {
+				types = append(types, clear)
+			}
+		}
+	}
+	return types
+}
+
+// clearType returns the type of the line clear made by a piece which removed the rows with the given kind of T-spin.
+// It returns an empty string if the piece made neither.
+func clearType(rows int, spin TSpin) ClearType {
+	// A T-spin mini which removes more rows than a mini can is counted as a T-spin
+	if spin == TSpinMini && rows >= len(clearTypes[TSpinMini]) {
+		spin = TSpinFull
+	}
+	types := clearTypes[spin]
+	if rows >= len(types) {
+		return ""
+	}
+	return types[rows]
+}
+
+// addLineClear updates the score, the cleared lines, the level, the combo and the back-to-back chain after a piece is fixed.
+// It returns the type of the line clear, which is empty if the piece made neither a line clear nor a T-spin.
+func (g *Game) addLineClear(rows int, spin TSpin) ClearType {
+	if rows == 0 {
+		g.Combo = 0
+	} else {
+		g.Combo++
+	}
+
+	clear := clearType(rows, spin)
+	if clear == "" {
+		return ""
+	}
+
+	points := clearScores[clear]
+	if rows > 0 {
+		if clear == ClearQuad || spin != NoTSpin {
+			g.BackToBack++
+			// Difficult line clears in a row earn 1.5 times the points
+			if g.BackToBack > 1 {
+				points = points * 3 / 2
+			}
+		} else {
+			g.BackToBack = 0
+		}
+		points += ComboScore * (g.Combo - 1)
+	}
+
+	g.Score += points * g.Level
+	g.LastClear = clear
+	g.Lines += rows
+	g.Level = g.Lines/LinesPerLevel + 1
+	return clear
+}
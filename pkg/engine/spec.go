@@ -0,0 +1,120 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+// KickTable is the table of wall kicks used when a piece rotates.
+type KickTable string
+
+const (
+	// Wall kicks of the Super Rotation System for J, L, S, T and Z minoes
+	StandardKickTable KickTable = "Standard"
+	// Wall kicks of the Super Rotation System for I mino
+	IKickTable KickTable = "I"
+	// No wall kicks. The piece rotates only in place.
+	NoKickTable KickTable = "None"
+)
+
+// Kick is a list of the offsets of the center tried in order when a piece rotates from `From` to `To`.
+// Positive "y" means upward.
+type Kick struct {
+	From    int
+	To      int
+	Offsets []Coord
+}
+
+// Spec defines the shape of a mino and how it rotates.
+type Spec struct {
+	// Coordinates of the cells relative to the center in the spawn state
+	Coords []Coord
+	// Coordinates of the cells in each of the 4 rotation states. If empty, Coords is rotated around the center.
+	Rotations [][]Coord
+	// Wall kick table used when Kicks does not define the rotation
+	KickTable KickTable
+	// Wall kicks which take precedence over KickTable
+	Kicks []Kick
+}
+
+type rotation struct {
+	from, to int
+}
+
+// Wall kicks of the Super Rotation System for J, L, S, T and Z minoes. Positive "y" means upward.
+var standardKicks = map[rotation][]Coord{
+	{0, 1}: {{X: 0, Y: 0}, {X: -1, Y: 0}, {X: -1, Y: 1}, {X: 0, Y: -2}, {X: -1, Y: -2}},
+	{1, 0}: {{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: -1}, {X: 0, Y: 2}, {X: 1, Y: 2}},
+	{1, 2}: {{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: -1}, {X: 0, Y: 2}, {X: 1, Y: 2}},
+	{2, 1}: {{X: 0, Y: 0}, {X: -1, Y: 0}, {X: -1, Y: 1}, {X: 0, Y: -2}, {X: -1, Y: -2}},
+	{2, 3}: {{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: -2}, {X: 1, Y: -2}},
+	{3, 2}: {{X: 0, Y: 0}, {X: -1, Y: 0}, {X: -1, Y: -1}, {X: 0, Y: 2}, {X: -1, Y: 2}},
+	{3, 0}: {{X: 0, Y: 0}, {X: -1, Y: 0}, {X: -1, Y: -1}, {X: 0, Y: 2}, {X: -1, Y: 2}},
+	{0, 3}: {{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 1, Y: 1}, {X: 0, Y: -2}, {X: 1, Y: -2}},
+}
+
+// Wall kicks of the Super Rotation System for I mino. Positive "y" means upward.
+var iKicks = map[rotation][]Coord{
+	{0, 1}: {{X: 0, Y: 0}, {X: -2, Y: 0}, {X: 1, Y: 0}, {X: -2, Y: -1}, {X: 1, Y: 2}},
+	{1, 0}: {{X: 0, Y: 0}, {X: 2, Y: 0}, {X: -1, Y: 0}, {X: 2, Y: 1}, {X: -1, Y: -2}},
+	{1, 2}: {{X: 0, Y: 0}, {X: -1, Y: 0}, {X: 2, Y: 0}, {X: -1, Y: 2}, {X: 2, Y: -1}},
+	{2, 1}: {{X: 0, Y: 0}, {X: 1, Y: 0}, {X: -2, Y: 0}, {X: 1, Y: -2}, {X: -2, Y: 1}},
+	{2, 3}: {{X: 0, Y: 0}, {X: 2, Y: 0}, {X: -1, Y: 0}, {X: 2, Y: 1}, {X: -1, Y: -2}},
+	{3, 2}: {{X: 0, Y: 0}, {X: -2, Y: 0}, {X: 1, Y: 0}, {X: -2, Y: -1}, {X: 1, Y: 2}},
+	{3, 0}: {{X: 0, Y: 0}, {X: 1, Y: 0}, {X: -2, Y: 0}, {X: 1, Y: -2}, {X: -2, Y: 1}},
+	{0, 3}: {{X: 0, Y: 0}, {X: -1, Y: 0}, {X: 2, Y: 0}, {X: -1, Y: 2}, {X: 2, Y: -1}},
+}
+
+// Wall kicks for 180 degree rotations, which are not defined by the Super Rotation System.
+var halfTurnKicks = []Coord{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 0}, {X: -1, Y: 0}, {X: 0, Y: -1}}
+
+// KickOffsets returns the offsets of the center tried in order when the piece rotates from `from` to `to`.
+func (s Spec) KickOffsets(from, to int) []Coord {
+	for _, kick := range s.Kicks {
+		if kick.From == from && kick.To == to {
+			return kick.Offsets
+		}
+	}
+
+	noKick := []Coord{{X: 0, Y: 0}}
+	switch {
+	case s.KickTable == NoKickTable:
+		return noKick
+	case (to-from+4)%4 == 2:
+		return halfTurnKicks
+	case s.KickTable == IKickTable:
+		return iKicks[rotation{from, to}]
+	default:
+		return standardKicks[rotation{from, to}]
+	}
+}
+
+// Shape returns the relative coordinates of the cells in the given rotation state.
+func (s Spec) Shape(rotation int) []Coord {
+	if len(s.Rotations) == 4 {
+		return append([]Coord{}, s.Rotations[rotation]...)
+	}
+	return RotateCoords(s.Coords, rotation)
+}
+
+// RotateCoords rotates the relative coordinates clockwise by the given number of quarter turns.
+func RotateCoords(coords []Coord, turns int) []Coord {
+	rotated := append([]Coord{}, coords...)
+	for i := 0; i < turns; i++ {
+		for j, c := range rotated {
+			rotated[j] = Coord{X: c.Y, Y: -c.X}
+		}
+	}
+	return rotated
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2022.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package engine
+
+// TSpin is the kind of T-spin made by a piece.
+type TSpin int
+
+const (
+	NoTSpin TSpin = iota
+	TSpinMini
+	TSpinFull
+)
+
+// Index of the last wall kick offset of the Super Rotation System. A T-spin mini made with it is counted as a T-spin.
+const TSpinUpgradeKick = 4
+
+// Corners around the center of a T mino in relative coordinates.
+var tCorners = []Coord{{X: -1, Y: 1}, {X: 1, Y: 1}, {X: -1, Y: -1}, {X: 1, Y: -1}}
+
+// TSpin returns the kind of T-spin made by the piece, following the 3-corner rule.
+// The piece must be a T mino whose last successful move was a rotation, and at least 3 of the corners around its center must be occupied.
+// It is a T-spin if both corners on the pointing side are occupied, otherwise a T-spin mini.
+func (b Board) TSpin(p Piece) TSpin {
+	if !p.Rotated {
+		return NoTSpin
+	}
+	front, ok := tFront(p.Shape)
+	if !ok {
+		return NoTSpin
+	}
+
+	corners, fronts := 0, 0
+	for _, c := range tCorners {
+		// Walls and the floor count as occupied
+		if !b.Collides([]Coord{{X: p.Center.X + c.X, Y: p.Center.Y - c.Y}}) {
+			continue
+		}
+		corners++
+		if c.X*front.X+c.Y*front.Y > 0 {
+			fronts++
+		}
+	}
+
+	switch {
+	case corners < 3:
+		return NoTSpin
+	case fronts == 2 || p.KickIndex == TSpinUpgradeKick:
+		return TSpinFull
+	default:
+		return TSpinMini
+	}
+}
+
+// tFront returns the direction in which a T mino points, given its relative coordinates.
+// It returns false if the mino is not shaped like a T, i.e. its center and 3 of the 4 cells next to it.
+func tFront(coords []Coord) (Coord, bool) {
+	if len(coords) != 4 {
+		return Coord{}, false
+	}
+	center := false
+	front := Coord{}
+	for _, c := range coords {
+		switch {
+		case c.X == 0 && c.Y == 0:
+			center = true
+		case c.X*c.X+c.Y*c.Y == 1:
+			// The cells on both sides cancel each other out
+			front.X += c.X
+			front.Y += c.Y
+		default:
+			return Coord{}, false
+		}
+	}
+	return front, center
+}